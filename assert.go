@@ -26,6 +26,16 @@ See failed test output (assert_failed_test.go):
 
 	go test -assert.failed
 
+AssertFasterThan compares with baseline files in testdata, which are
+rewritten by running the tests with:
+
+	go test -assert.update
+
+Unlike -assert.failed, which belongs to the tests of this package, the
+-assert.update flag is registered by the package itself, so that it exists
+in the test binary of every package that imports assert. Such a package
+must not register a flag of the same name.
+
 Report bugs to <chaishushan@gmail.com>.
 
 Thanks!
@@ -36,10 +46,8 @@ import (
 	"fmt"
 	"image"
 	"image/color"
-	"math"
 	"os"
 	"reflect"
-	"regexp"
 	"testing"
 )
 
@@ -133,12 +141,24 @@ func AssertFalse(tb testing.TB, condition bool, args ...interface{}) {
 	}
 }
 
+// AssertEqual compares the %v formatting of expected and got. So NaN
+// equals NaN (NaNEqual is accepted but implied), while -0 and +0 differ,
+// and so do 1 and 1.0000000000000002.
 func AssertEqual(tb testing.TB, expected, got interface{}, args ...interface{}) {
 	if x, ok := tb.(testing_TBHelper); ok {
 		x.Helper()
 	}
+	_, args = tSplitOptions(args)
 	// reflect.DeepEqual is failed for `int == int64?`
 	if fmt.Sprintf("%v", expected) != fmt.Sprintf("%v", got) {
+		if a, b, ok := tMultilineStrings(expected, got); ok {
+			if msg := fmt.Sprint(args...); msg != "" {
+				tb.Fatalf("AssertEqual failed, %s\n%s", msg, tStringDiff(a, b))
+			} else {
+				tb.Fatalf("AssertEqual failed\n%s", tStringDiff(a, b))
+			}
+			return
+		}
 		if msg := fmt.Sprint(args...); msg != "" {
 			tb.Fatalf("AssertEqual failed, expected = %v, got = %v, %s", expected, got, msg)
 		} else {
@@ -151,7 +171,7 @@ func AssertNotEqual(tb testing.TB, expected, got interface{}, args ...interface{
 	if x, ok := tb.(testing_TBHelper); ok {
 		x.Helper()
 	}
-
+	_, args = tSplitOptions(args)
 	// reflect.DeepEqual is failed for `int == int64?`
 	if fmt.Sprintf("%v", expected) == fmt.Sprintf("%v", got) {
 		if msg := fmt.Sprint(args...); msg != "" {
@@ -162,11 +182,15 @@ func AssertNotEqual(tb testing.TB, expected, got interface{}, args ...interface{
 	}
 }
 
+// AssertNear checks |expected-got| <= abs. -0 and +0 are equal, an
+// infinity is only near the same infinity, and NaN is near nothing unless
+// the NaNEqual option is given, which makes NaN near NaN.
 func AssertNear(tb testing.TB, expected, got, abs float64, args ...interface{}) {
 	if x, ok := tb.(testing_TBHelper); ok {
 		x.Helper()
 	}
-	if math.Abs(expected-got) > abs {
+	opts, args := tSplitOptions(args)
+	if !tNear(expected, got, abs, opts.NaNEqual) {
 		if msg := fmt.Sprint(args...); msg != "" {
 			tb.Fatalf("AssertNear failed, expected = %v, got = %v, abs = %v, %s", expected, got, abs, msg)
 		} else {
@@ -205,18 +229,18 @@ func AssertMatch(tb testing.TB, expectedPattern string, got []byte, args ...inte
 	if x, ok := tb.(testing_TBHelper); ok {
 		x.Helper()
 	}
-	if matched, err := regexp.Match(expectedPattern, got); err != nil || !matched {
+	if re, err := tCompileRegexp(expectedPattern); err != nil || !re.Match(got) {
 		if err != nil {
 			if msg := fmt.Sprint(args...); msg != "" {
-				tb.Fatalf("AssertMatch failed, expected = %q, got = %v, err = %v, %s", expectedPattern, got, err, msg)
+				tb.Fatalf("AssertMatch failed, expected = %q, got = %q, err = %v, %s", expectedPattern, got, err, msg)
 			} else {
-				tb.Fatalf("AssertMatch failed, expected = %q, got = %v, err = %v", expectedPattern, got, err)
+				tb.Fatalf("AssertMatch failed, expected = %q, got = %q, err = %v", expectedPattern, got, err)
 			}
 		} else {
 			if msg := fmt.Sprint(args...); msg != "" {
-				tb.Fatalf("AssertMatch failed, expected = %q, got = %v, %s", expectedPattern, got, msg)
+				tb.Fatalf("AssertMatch failed, expected = %q, got = %q, %s", expectedPattern, got, msg)
 			} else {
-				tb.Fatalf("AssertMatch failed, expected = %q, got = %v", expectedPattern, got)
+				tb.Fatalf("AssertMatch failed, expected = %q, got = %q", expectedPattern, got)
 			}
 		}
 	}
@@ -226,7 +250,7 @@ func AssertMatchString(tb testing.TB, expectedPattern, got string, args ...inter
 	if x, ok := tb.(testing_TBHelper); ok {
 		x.Helper()
 	}
-	if matched, err := regexp.MatchString(expectedPattern, got); err != nil || !matched {
+	if re, err := tCompileRegexp(expectedPattern); err != nil || !re.MatchString(got) {
 		if err != nil {
 			if msg := fmt.Sprint(args...); msg != "" {
 				tb.Fatalf("AssertMatchString failed, expected = %q, got = %v, err = %v, %s", expectedPattern, got, err, msg)
@@ -460,7 +484,7 @@ func AssertZero(tb testing.TB, val interface{}, args ...interface{}) {
 	if x, ok := tb.(testing_TBHelper); ok {
 		x.Helper()
 	}
-	if !reflect.DeepEqual(reflect.Zero(reflect.TypeOf(val)).Interface(), val) {
+	if val != nil && !reflect.DeepEqual(reflect.Zero(reflect.TypeOf(val)).Interface(), val) {
 		if msg := fmt.Sprint(args...); msg != "" {
 			tb.Fatalf("AssertZero failed, val = %v, %s", val, msg)
 		} else {
@@ -473,7 +497,7 @@ func AssertNotZero(tb testing.TB, val interface{}, args ...interface{}) {
 	if x, ok := tb.(testing_TBHelper); ok {
 		x.Helper()
 	}
-	if reflect.DeepEqual(reflect.Zero(reflect.TypeOf(val)).Interface(), val) {
+	if val == nil || reflect.DeepEqual(reflect.Zero(reflect.TypeOf(val)).Interface(), val) {
 		if msg := fmt.Sprint(args...); msg != "" {
 			tb.Fatalf("AssertNotZero failed, val = %v, %s", val, msg)
 		} else {
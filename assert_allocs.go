@@ -0,0 +1,162 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// tAllocsRuns is the number of runs averaged by the allocation assertions.
+const tAllocsRuns = 100
+
+// AssertAllocs checks that f allocates at most maxAllocs times per run, on
+// average, as measured by testing.AllocsPerRun.
+//
+// With the HeapProfile option, a failure also writes the allocations of
+// 100 runs of f, by stack, to a temporary file.
+func AssertAllocs(tb testing.TB, maxAllocs float64, f func(), args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	tAssertAllocs(tb, "AssertAllocs", maxAllocs, f, args)
+}
+
+// AssertNoAllocs checks that f does not allocate. It accepts the same
+// options as AssertAllocs.
+func AssertNoAllocs(tb testing.TB, f func(), args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	tAssertAllocs(tb, "AssertNoAllocs", 0, f, args)
+}
+
+func tAssertAllocs(tb testing.TB, name string, maxAllocs float64, f func(), args []interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	opts, args := tSplitOptions(args)
+	avg := testing.AllocsPerRun(tAllocsRuns, f)
+	if avg <= maxAllocs {
+		return
+	}
+
+	var profile string
+	if opts.HeapProfile {
+		if path, err := tWriteHeapProfile(f); err != nil {
+			profile = fmt.Sprintf("\nheap profile: %v", err)
+		} else {
+			profile = fmt.Sprintf("\nheap profile: %s", path)
+		}
+	}
+	if msg := fmt.Sprint(args...); msg != "" {
+		tb.Fatalf("%s failed, max = %v, got = %v allocs/run, %s%s", name, maxAllocs, avg, msg, profile)
+	} else {
+		tb.Fatalf("%s failed, max = %v, got = %v allocs/run%s", name, maxAllocs, avg, profile)
+	}
+}
+
+// tWriteHeapProfile records every allocation of tAllocsRuns runs of f, and
+// writes the difference of the heap profile, by stack, to a temporary file.
+func tWriteHeapProfile(f func()) (string, error) {
+	rate := runtime.MemProfileRate
+	runtime.MemProfileRate = 1
+	defer func() { runtime.MemProfileRate = rate }()
+
+	before := tMemProfile()
+	for i := 0; i < tAllocsRuns; i++ {
+		f()
+	}
+	after := tMemProfile()
+
+	type entry struct {
+		stack   string
+		objects int64
+		bytes   int64
+	}
+	var entries []entry
+	var objects, bytes int64
+	for stack, r := range after {
+		b := before[stack]
+		e := entry{
+			stack:   stack,
+			objects: r.AllocObjects - b.AllocObjects,
+			bytes:   r.AllocBytes - b.AllocBytes,
+		}
+		if e.objects > 0 {
+			entries = append(entries, e)
+			objects += e.objects
+			bytes += e.bytes
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].objects != entries[j].objects {
+			return entries[i].objects > entries[j].objects
+		}
+		return entries[i].stack < entries[j].stack
+	})
+
+	file, err := os.CreateTemp("", "assert-allocs-*.txt")
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(file, "%d runs, %d objects, %d bytes\n", tAllocsRuns, objects, bytes)
+	for _, e := range entries {
+		fmt.Fprintf(file, "\n%d objects, %d bytes\n%s", e.objects, e.bytes, e.stack)
+	}
+	if err := file.Close(); err != nil {
+		return "", err
+	}
+	return file.Name(), nil
+}
+
+// tMemProfile returns the current heap profile keyed by symbolized stack.
+func tMemProfile() map[string]runtime.MemProfileRecord {
+	// Allocations are published to the profile by garbage collections.
+	runtime.GC()
+	runtime.GC()
+
+	var records []runtime.MemProfileRecord
+	n, _ := runtime.MemProfile(nil, true)
+	for {
+		records = make([]runtime.MemProfileRecord, n+50)
+		var ok bool
+		if n, ok = runtime.MemProfile(records, true); ok {
+			records = records[:n]
+			break
+		}
+	}
+
+	profile := make(map[string]runtime.MemProfileRecord)
+	for _, r := range records {
+		stack := tFormatStack(r.Stack())
+		if strings.Contains(stack, "assert.tMemProfile\n") {
+			continue // the profiling itself
+		}
+		if x, ok := profile[stack]; ok {
+			r.AllocObjects += x.AllocObjects
+			r.AllocBytes += x.AllocBytes
+		}
+		profile[stack] = r
+	}
+	return profile
+}
+
+func tFormatStack(pcs []uintptr) string {
+	var buf strings.Builder
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&buf, "\t%s\n\t\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,31 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// go test -assert.failed
+
+package assert
+
+import (
+	"testing"
+)
+
+var tAllocsFailedSink [][]byte
+
+func TestAssertAllocs_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertAllocs(t, 1, func() {
+		tAllocsFailedSink = append(tAllocsFailedSink[:0], make([]byte, 64), make([]byte, 128))
+	}, HeapProfile)
+}
+
+func TestAssertNoAllocs_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertNoAllocs(t, func() {
+		tAllocsFailedSink = [][]byte{make([]byte, 8)}
+	}, "make in hot path")
+}
@@ -0,0 +1,27 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert_test
+
+import (
+	"strconv"
+	"testing"
+
+	. "github.com/chai2010/assert"
+)
+
+var tAllocsSink []byte
+
+func TestAssertAllocs(t *testing.T) {
+	AssertAllocs(t, 1, func() {
+		tAllocsSink = make([]byte, 64)
+	})
+}
+
+func TestAssertNoAllocs(t *testing.T) {
+	buf := make([]byte, 0, 32)
+	AssertNoAllocs(t, func() {
+		buf = strconv.AppendInt(buf[:0], 12345, 10)
+	})
+}
@@ -0,0 +1,142 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// flagAssertUpdate is registered by the package on purpose, see the
+// package doc.
+var (
+	flagAssertUpdate = flag.Bool("assert.update", false, "rewrite the files in testdata that assertions compare with")
+)
+
+// tBaseline is the content of a baseline file written by AssertFasterThan.
+type tBaseline struct {
+	NsPerOp     int64 `json:"ns_per_op"`
+	BytesPerOp  int64 `json:"bytes_per_op"`
+	AllocsPerOp int64 `json:"allocs_per_op"`
+}
+
+// AssertFasterThan runs f with testing.Benchmark and compares its ns/op and
+// B/op with the baseline stored in testdata/<baselineName>.baseline.json.
+// It fails if either is more than tolerance (0.1 for 10%) above the
+// baseline.
+//
+// Running the tests with -assert.update writes the measured values as the
+// new baseline instead.
+func AssertFasterThan(tb testing.TB, baselineName string, f func(b *testing.B), tolerance float64, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	path := filepath.Join("testdata", baselineName+".baseline.json")
+	r, why := tRunBenchmark(f)
+	if why != "" {
+		tb.Fatalf("AssertFasterThan called with a benchmark that %s", why)
+	}
+	got := tBaseline{
+		NsPerOp:     r.NsPerOp(),
+		BytesPerOp:  r.AllocedBytesPerOp(),
+		AllocsPerOp: r.AllocsPerOp(),
+	}
+
+	report, err := tCheckBaseline(path, got, tolerance, *flagAssertUpdate)
+	switch {
+	case err != nil && *flagAssertUpdate:
+		tb.Fatalf("AssertFasterThan failed to write %s, err = %v", path, err)
+	case errors.Is(err, fs.ErrNotExist):
+		tb.Fatalf("AssertFasterThan called with missing baseline %s, run go test -assert.update to create it", path)
+	case err != nil:
+		tb.Fatalf("AssertFasterThan called with invalid baseline %s, err = %v", path, err)
+	case *flagAssertUpdate:
+		tb.Logf("AssertFasterThan wrote %s: %d ns/op, %d B/op", path, got.NsPerOp, got.BytesPerOp)
+	case report != "":
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertFasterThan failed, baseline = %s, tolerance = %v, %s\n%s", path, tolerance, msg, report)
+		} else {
+			tb.Fatalf("AssertFasterThan failed, baseline = %s, tolerance = %v\n%s", path, tolerance, report)
+		}
+	}
+}
+
+// tRunBenchmark runs f with testing.Benchmark. If there is no result, it
+// also returns why, since testing.Benchmark drops the log of f.
+func tRunBenchmark(f func(b *testing.B)) (r testing.BenchmarkResult, why string) {
+	var failed, skipped bool
+	r = testing.Benchmark(func(b *testing.B) {
+		defer func() { failed, skipped = b.Failed(), b.Skipped() }()
+		f(b)
+	})
+	switch {
+	case failed:
+		return r, "failed"
+	case skipped:
+		return r, "was skipped"
+	case r.N == 0:
+		return r, "did not run"
+	}
+	return r, ""
+}
+
+// tCheckBaseline writes got as the baseline at path if update is set.
+// Otherwise it compares got with the baseline and returns a report of the
+// regression, or "" if there is none.
+func tCheckBaseline(path string, got tBaseline, tolerance float64, update bool) (report string, err error) {
+	if update {
+		return "", tWriteBaseline(path, got)
+	}
+	base, err := tReadBaseline(path)
+	if err != nil {
+		return "", err
+	}
+	nsSlow := float64(got.NsPerOp) > float64(base.NsPerOp)*(1+tolerance)
+	bytesSlow := float64(got.BytesPerOp) > float64(base.BytesPerOp)*(1+tolerance)
+	if !nsSlow && !bytesSlow {
+		return "", nil
+	}
+	return fmt.Sprintf("\tns/op: baseline = %d, got = %d (%s)\n\tB/op:  baseline = %d, got = %d (%s)",
+		base.NsPerOp, got.NsPerOp, tChange(base.NsPerOp, got.NsPerOp),
+		base.BytesPerOp, got.BytesPerOp, tChange(base.BytesPerOp, got.BytesPerOp),
+	), nil
+}
+
+func tReadBaseline(path string) (base tBaseline, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return base, err
+	}
+	err = json.Unmarshal(data, &base)
+	return base, err
+}
+
+func tWriteBaseline(path string, base tBaseline) error {
+	data, err := json.MarshalIndent(base, "", "\t")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// tChange formats the relative change from base to got, such as "+12.5%".
+func tChange(base, got int64) string {
+	if base == 0 {
+		if got == 0 {
+			return "+0%"
+		}
+		return "new"
+	}
+	return fmt.Sprintf("%+.1f%%", 100*float64(got-base)/float64(base))
+}
@@ -0,0 +1,30 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// go test -assert.failed
+
+package assert
+
+import (
+	"testing"
+)
+
+func TestAssertFasterThan_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertFasterThan(t, "sum_failed", func(b *testing.B) {
+		var buf [][]byte
+		for i := 0; i < b.N; i++ {
+			buf = append(buf[:0], make([]byte, 64))
+		}
+	}, 0.1)
+}
+
+func TestAssertFasterThan_failed_missing(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertFasterThan(t, "missing", func(b *testing.B) {}, 0.1)
+}
@@ -0,0 +1,64 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckBaseline(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdata", "sum.baseline.json")
+	base := tBaseline{NsPerOp: 100, BytesPerOp: 64, AllocsPerOp: 1}
+
+	_, err := tCheckBaseline(path, base, 0.1, false)
+	AssertTrue(t, errors.Is(err, fs.ErrNotExist), err)
+
+	report, err := tCheckBaseline(path, base, 0.1, true)
+	AssertNil(t, err)
+	AssertEqual(t, "", report)
+	got, err := tReadBaseline(path)
+	AssertNil(t, err)
+	AssertEqual(t, base, got)
+
+	for _, tt := range []struct {
+		got    tBaseline
+		report string
+	}{
+		{tBaseline{NsPerOp: 110, BytesPerOp: 64}, ""},
+		{tBaseline{NsPerOp: 50, BytesPerOp: 0}, ""},
+		{tBaseline{NsPerOp: 111, BytesPerOp: 64},
+			"\tns/op: baseline = 100, got = 111 (+11.0%)\n\tB/op:  baseline = 64, got = 64 (+0.0%)"},
+		{tBaseline{NsPerOp: 100, BytesPerOp: 128},
+			"\tns/op: baseline = 100, got = 100 (+0.0%)\n\tB/op:  baseline = 64, got = 128 (+100.0%)"},
+	} {
+		report, err := tCheckBaseline(path, tt.got, 0.1, false)
+		AssertNil(t, err)
+		AssertEqual(t, tt.report, report, tt.got)
+	}
+
+	// Updating replaces the baseline.
+	faster := tBaseline{NsPerOp: 50, BytesPerOp: 32}
+	_, err = tCheckBaseline(path, faster, 0.1, true)
+	AssertNil(t, err)
+	report, err = tCheckBaseline(path, tBaseline{NsPerOp: 100, BytesPerOp: 32}, 0.1, false)
+	AssertNil(t, err)
+	AssertHasPrefix(t, report, "\tns/op: baseline = 50, got = 100 (+100.0%)")
+}
+
+func TestRunBenchmark(t *testing.T) {
+	_, why := tRunBenchmark(func(b *testing.B) { b.Fatal("broken") })
+	AssertEqual(t, "failed", why)
+	_, why = tRunBenchmark(func(b *testing.B) { b.Skip("not here") })
+	AssertEqual(t, "was skipped", why)
+}
+
+func TestChange(t *testing.T) {
+	AssertEqual(t, "+0%", tChange(0, 0))
+	AssertEqual(t, "new", tChange(0, 5))
+	AssertEqual(t, "-50.0%", tChange(10, 5))
+}
@@ -0,0 +1,110 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+// AssertBigEqual compares two *big.Int, *big.Rat or *big.Float values of
+// the same type with Cmp, so that the precision of a big.Float and the
+// representation of a big.Rat do not matter.
+func AssertBigEqual(tb testing.TB, expected, got interface{}, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	var equal bool
+	switch e := expected.(type) {
+	case *big.Int:
+		g, ok := got.(*big.Int)
+		if !ok {
+			tb.Fatalf("AssertBigEqual called with mismatched types %T and %T", expected, got)
+		}
+		equal = e == nil && g == nil || e != nil && g != nil && e.Cmp(g) == 0
+	case *big.Rat:
+		g, ok := got.(*big.Rat)
+		if !ok {
+			tb.Fatalf("AssertBigEqual called with mismatched types %T and %T", expected, got)
+		}
+		equal = e == nil && g == nil || e != nil && g != nil && e.Cmp(g) == 0
+	case *big.Float:
+		g, ok := got.(*big.Float)
+		if !ok {
+			tb.Fatalf("AssertBigEqual called with mismatched types %T and %T", expected, got)
+		}
+		equal = e == nil && g == nil || e != nil && g != nil && e.Cmp(g) == 0
+	default:
+		tb.Fatalf("AssertBigEqual called with non-big value of type %T", expected)
+	}
+	if !equal {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertBigEqual failed, expected = %s, got = %s, %s", tBigString(expected), tBigString(got), msg)
+		} else {
+			tb.Fatalf("AssertBigEqual failed, expected = %s, got = %s", tBigString(expected), tBigString(got))
+		}
+	}
+}
+
+// AssertBigNear checks |expected-got| <= rel * max(|expected|, |got|),
+// computed at the larger precision of expected and got.
+func AssertBigNear(tb testing.TB, expected, got *big.Float, rel float64, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	if expected == nil || got == nil {
+		tb.Fatalf("AssertBigNear called with nil value")
+	}
+	prec := expected.Prec()
+	if got.Prec() > prec {
+		prec = got.Prec()
+	}
+
+	var near bool
+	diff := new(big.Float).SetPrec(prec)
+	if expected.IsInf() || got.IsInf() {
+		near = expected.Cmp(got) == 0
+		if !near {
+			diff.SetInf(false)
+		}
+	} else {
+		diff.Sub(expected, got).Abs(diff)
+		bound := new(big.Float).SetPrec(prec).Abs(expected)
+		if g := new(big.Float).SetPrec(prec).Abs(got); g.Cmp(bound) > 0 {
+			bound = g
+		}
+		bound.Mul(bound, new(big.Float).SetPrec(prec).SetFloat64(rel))
+		near = diff.Cmp(bound) <= 0
+	}
+	if !near {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertBigNear failed, expected = %s, got = %s, rel = %v, abs diff = %s, %s", tBigString(expected), tBigString(got), rel, diff.Text('g', 10), msg)
+		} else {
+			tb.Fatalf("AssertBigNear failed, expected = %s, got = %s, rel = %v, abs diff = %s", tBigString(expected), tBigString(got), rel, diff.Text('g', 10))
+		}
+	}
+}
+
+// tBigString formats a big number in full precision.
+func tBigString(v interface{}) string {
+	switch x := v.(type) {
+	case *big.Int:
+		if x != nil {
+			return x.String()
+		}
+	case *big.Rat:
+		if x != nil {
+			return x.RatString()
+		}
+	case *big.Float:
+		if x != nil {
+			return fmt.Sprintf("%s (prec %d)", x.Text('g', -1), x.Prec())
+		}
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+	return "<nil>"
+}
@@ -0,0 +1,44 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// go test -assert.failed
+
+package assert
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestAssertBigEqual_failed_01(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	a, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	b, _ := new(big.Int).SetString("123456789012345678901234567891", 10)
+	AssertBigEqual(t, a, b)
+}
+
+func TestAssertBigEqual_failed_02(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	third := new(big.Float).SetPrec(200).Quo(big.NewFloat(1), big.NewFloat(3))
+	AssertBigEqual(t, big.NewFloat(1.0/3), third)
+}
+
+func TestAssertBigEqual_failed_03(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertBigEqual(t, big.NewRat(1, 3), big.NewRat(333, 1000))
+}
+
+func TestAssertBigNear_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	third := new(big.Float).SetPrec(200).Quo(big.NewFloat(1), big.NewFloat(3))
+	AssertBigNear(t, big.NewFloat(1.0/3), third, 1e-20)
+}
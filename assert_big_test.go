@@ -0,0 +1,27 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert_test
+
+import (
+	"math/big"
+	"testing"
+
+	. "github.com/chai2010/assert"
+)
+
+func TestAssertBigEqual(t *testing.T) {
+	a, _ := new(big.Int).SetString("1267650600228229401496703205376", 10)
+	AssertBigEqual(t, a, new(big.Int).Lsh(big.NewInt(1), 100))
+
+	AssertBigEqual(t, big.NewRat(1, 2), big.NewRat(3, 6))
+	AssertBigEqual(t, big.NewFloat(0.5), new(big.Float).SetPrec(200).SetRat(big.NewRat(1, 2)))
+	AssertBigEqual(t, (*big.Int)(nil), (*big.Int)(nil))
+}
+
+func TestAssertBigNear(t *testing.T) {
+	third := new(big.Float).SetPrec(200).Quo(big.NewFloat(1), big.NewFloat(3))
+	AssertBigNear(t, big.NewFloat(1.0/3), third, 1e-15)
+	AssertBigNear(t, new(big.Float).SetInf(true), new(big.Float).SetInf(true), 0)
+}
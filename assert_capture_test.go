@@ -0,0 +1,35 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// tCaptureTB records the errors reported through it. Fatalf does not stop
+// the caller.
+type tCaptureTB struct {
+	testing.TB
+	errors []string
+}
+
+func (x *tCaptureTB) Errorf(format string, args ...interface{}) {
+	x.errors = append(x.errors, fmt.Sprintf(format, args...))
+}
+
+func (x *tCaptureTB) Fatalf(format string, args ...interface{}) {
+	x.errors = append(x.errors, fmt.Sprintf(format, args...))
+}
+
+// tSiteOf calls f and returns the file:line of its own call, on which f
+// should be written.
+func tSiteOf(f func()) string {
+	_, file, line, _ := runtime.Caller(1)
+	f()
+	return fmt.Sprintf("%s:%d", filepath.Base(file), line)
+}
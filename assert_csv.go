@@ -0,0 +1,362 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// CSVOptions controls how AssertCSVEqual matches two tables.
+// Rows and columns are numbered from 1, counting the header row.
+type CSVOptions struct {
+	// Header means the first record holds column names. Columns are then
+	// matched by name instead of by position.
+	Header bool
+
+	// IgnoreColumnOrder accepts the same named columns in any order.
+	// It requires Header.
+	IgnoreColumnOrder bool
+
+	// KeyColumn matches rows by the value of the named column, ignoring
+	// row order. It requires Header.
+	KeyColumn string
+
+	// Tolerance maps a column to the absolute difference allowed between
+	// numeric cells. Columns are named by header, or by their 1-based
+	// position ("1", "2", ...) without a header.
+	Tolerance map[string]float64
+}
+
+// tCSVMaxDiffs limits the number of differences listed by AssertCSVEqual.
+const tCSVMaxDiffs = 20
+
+type tCSVDiff struct {
+	ExpectedRow int // 0 if the row is missing in expected
+	GotRow      int // 0 if the row is missing in got
+	Col         string
+	Msg         string
+}
+
+func (d tCSVDiff) sortRow() int {
+	if d.ExpectedRow != 0 {
+		return d.ExpectedRow
+	}
+	return d.GotRow
+}
+
+func AssertCSVEqual(tb testing.TB, expected, got []byte, opts *CSVOptions, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	if opts == nil {
+		opts = new(CSVOptions)
+	}
+	if !opts.Header && (opts.IgnoreColumnOrder || opts.KeyColumn != "") {
+		tb.Fatalf("AssertCSVEqual called with IgnoreColumnOrder or KeyColumn but without Header")
+	}
+	expectedRecords, err := tReadCSV(expected)
+	if err != nil {
+		tb.Fatalf("AssertCSVEqual called with invalid expected value, err = %v", err)
+	}
+	gotRecords, err := tReadCSV(got)
+	if err != nil {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertCSVEqual failed, err = %v, %s", err, msg)
+		} else {
+			tb.Fatalf("AssertCSVEqual failed, err = %v", err)
+		}
+		return
+	}
+
+	diffs, err := tDiffCSV(expectedRecords, gotRecords, opts)
+	if err != nil {
+		tb.Fatalf("AssertCSVEqual called with invalid options, err = %v", err)
+	}
+	if len(diffs) != 0 {
+		report := tFormatCSVDiffs(expectedRecords, gotRecords, opts, diffs)
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertCSVEqual failed, %d differences, %s\n%s", len(diffs), msg, report)
+		} else {
+			tb.Fatalf("AssertCSVEqual failed, %d differences\n%s", len(diffs), report)
+		}
+	}
+}
+
+func tReadCSV(data []byte) ([][]string, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	return r.ReadAll()
+}
+
+// tCSVColumn pairs a named column with its index in both tables.
+type tCSVColumn struct {
+	Name     string
+	Expected int
+	Got      int
+}
+
+func tDiffCSV(expected, got [][]string, opts *CSVOptions) ([]tCSVDiff, error) {
+	var diffs []tCSVDiff
+	var columns []tCSVColumn
+	expectedRows, gotRows := 0, 0 // index of the first data row
+
+	if opts.Header {
+		var expectedHeader, gotHeader []string
+		if len(expected) > 0 {
+			expectedHeader = expected[0]
+			expectedRows = 1
+		}
+		if len(got) > 0 {
+			gotHeader = got[0]
+			gotRows = 1
+		}
+		columns, diffs = tMatchCSVHeader(expectedHeader, gotHeader, opts.IgnoreColumnOrder)
+		for i := expectedRows; i < len(expected); i++ {
+			if n := len(expected[i]); n != len(expectedHeader) {
+				diffs = append(diffs, tCSVDiff{ExpectedRow: i + 1, Msg: fmt.Sprintf("expected %d columns, got %d", len(expectedHeader), n)})
+			}
+		}
+		for i := gotRows; i < len(got); i++ {
+			if n := len(got[i]); n != len(gotHeader) {
+				diffs = append(diffs, tCSVDiff{GotRow: i + 1, Msg: fmt.Sprintf("expected %d columns, got %d", len(gotHeader), n)})
+			}
+		}
+	} else {
+		width := 0
+		for _, record := range expected {
+			if len(record) > width {
+				width = len(record)
+			}
+		}
+		for i := 0; i < width; i++ {
+			columns = append(columns, tCSVColumn{Name: strconv.Itoa(i + 1), Expected: i, Got: i})
+		}
+	}
+
+	type rowPair struct{ expected, got int }
+	var pairs []rowPair
+
+	if opts.KeyColumn != "" {
+		var key *tCSVColumn
+		for i := range columns {
+			if columns[i].Name == opts.KeyColumn {
+				key = &columns[i]
+				break
+			}
+		}
+		if key == nil || key.Expected < 0 {
+			return nil, fmt.Errorf("key column %q not found", opts.KeyColumn)
+		}
+		if key.Got < 0 {
+			// Rows cannot be paired; the header diff reports the column.
+			return diffs, nil
+		}
+		gotByKey := make(map[string]int)
+		for i := gotRows; i < len(got); i++ {
+			k := tCSVCell(got[i], key.Got)
+			if _, ok := gotByKey[k]; ok {
+				diffs = append(diffs, tCSVDiff{GotRow: i + 1, Col: key.Name, Msg: fmt.Sprintf("duplicate key %q", k)})
+				continue
+			}
+			gotByKey[k] = i
+		}
+		for i := expectedRows; i < len(expected); i++ {
+			k := tCSVCell(expected[i], key.Expected)
+			if j, ok := gotByKey[k]; ok {
+				pairs = append(pairs, rowPair{i, j})
+				delete(gotByKey, k)
+			} else {
+				diffs = append(diffs, tCSVDiff{ExpectedRow: i + 1, Msg: fmt.Sprintf("missing row with %s = %q", key.Name, k)})
+			}
+		}
+		for i := gotRows; i < len(got); i++ {
+			k := tCSVCell(got[i], key.Got)
+			if j, ok := gotByKey[k]; ok && j == i {
+				diffs = append(diffs, tCSVDiff{GotRow: i + 1, Msg: fmt.Sprintf("unexpected row with %s = %q", key.Name, k)})
+			}
+		}
+	} else {
+		for i, j := expectedRows, gotRows; i < len(expected) || j < len(got); i, j = i+1, j+1 {
+			switch {
+			case j >= len(got):
+				diffs = append(diffs, tCSVDiff{ExpectedRow: i + 1, Msg: fmt.Sprintf("missing row %q", expected[i])})
+			case i >= len(expected):
+				diffs = append(diffs, tCSVDiff{GotRow: j + 1, Msg: fmt.Sprintf("unexpected row %q", got[j])})
+			default:
+				pairs = append(pairs, rowPair{i, j})
+			}
+		}
+	}
+
+	for _, p := range pairs {
+		e, g := expected[p.expected], got[p.got]
+		// With a header, the rows were checked against its width above.
+		if !opts.Header && len(e) != len(g) {
+			diffs = append(diffs, tCSVDiff{
+				ExpectedRow: p.expected + 1, GotRow: p.got + 1,
+				Msg: fmt.Sprintf("expected %d columns, got %d", len(e), len(g)),
+			})
+		}
+		for _, col := range columns {
+			if col.Expected < 0 || col.Got < 0 {
+				continue
+			}
+			ev, gv := tCSVCell(e, col.Expected), tCSVCell(g, col.Got)
+			if tCSVCellEqual(ev, gv, opts.Tolerance, col.Name) {
+				continue
+			}
+			diffs = append(diffs, tCSVDiff{
+				ExpectedRow: p.expected + 1, GotRow: p.got + 1, Col: col.Name,
+				Msg: fmt.Sprintf("expected = %q, got = %q", ev, gv),
+			})
+		}
+	}
+	sort.SliceStable(diffs, func(i, j int) bool {
+		return diffs[i].sortRow() < diffs[j].sortRow()
+	})
+	return diffs, nil
+}
+
+func tMatchCSVHeader(expected, got []string, ignoreOrder bool) (columns []tCSVColumn, diffs []tCSVDiff) {
+	gotIndex := make(map[string]int)
+	for i, name := range got {
+		if _, ok := gotIndex[name]; !ok {
+			gotIndex[name] = i
+		}
+	}
+	expectedIndex := make(map[string]int)
+	for i, name := range expected {
+		if _, ok := expectedIndex[name]; ok {
+			continue
+		}
+		expectedIndex[name] = i
+		j, ok := gotIndex[name]
+		if !ok {
+			j = -1
+			diffs = append(diffs, tCSVDiff{ExpectedRow: 1, Col: name, Msg: "missing column"})
+		} else if !ignoreOrder && j != i {
+			diffs = append(diffs, tCSVDiff{ExpectedRow: 1, GotRow: 1, Col: name,
+				Msg: fmt.Sprintf("expected at column %d, got at column %d", i+1, j+1)})
+		}
+		columns = append(columns, tCSVColumn{Name: name, Expected: i, Got: j})
+	}
+	for i, name := range got {
+		if _, ok := expectedIndex[name]; !ok {
+			diffs = append(diffs, tCSVDiff{GotRow: 1, Col: name, Msg: fmt.Sprintf("unexpected column %d", i+1)})
+		}
+	}
+	return
+}
+
+func tCSVCell(record []string, i int) string {
+	if i >= 0 && i < len(record) {
+		return record[i]
+	}
+	return ""
+}
+
+func tCSVCellEqual(expected, got string, tolerance map[string]float64, col string) bool {
+	if expected == got {
+		return true
+	}
+	tol, ok := tolerance[col]
+	if !ok {
+		return false
+	}
+	a, err := strconv.ParseFloat(strings.TrimSpace(expected), 64)
+	if err != nil {
+		return false
+	}
+	b, err := strconv.ParseFloat(strings.TrimSpace(got), 64)
+	if err != nil {
+		return false
+	}
+	return math.Abs(a-b) <= tol
+}
+
+func tFormatCSVDiffs(expected, got [][]string, opts *CSVOptions, diffs []tCSVDiff) string {
+	var buf strings.Builder
+	for i, d := range diffs {
+		if i == tCSVMaxDiffs {
+			fmt.Fprintf(&buf, "\t... and %d more\n", len(diffs)-i)
+			break
+		}
+		buf.WriteString("\t")
+		switch {
+		case d.ExpectedRow == d.GotRow:
+			fmt.Fprintf(&buf, "row %d", d.GotRow)
+		case d.GotRow == 0:
+			fmt.Fprintf(&buf, "expected row %d", d.ExpectedRow)
+		case d.ExpectedRow == 0:
+			fmt.Fprintf(&buf, "got row %d", d.GotRow)
+		default:
+			fmt.Fprintf(&buf, "expected row %d, got row %d", d.ExpectedRow, d.GotRow)
+		}
+		if d.Col != "" {
+			fmt.Fprintf(&buf, ", column %s", d.Col)
+		}
+		fmt.Fprintf(&buf, ": %s\n", d.Msg)
+	}
+
+	first := diffs[0]
+	if first.ExpectedRow != 0 {
+		fmt.Fprintf(&buf, "expected:\n%s", tCSVExcerpt(expected, opts.Header, first.ExpectedRow))
+	}
+	if first.GotRow != 0 {
+		fmt.Fprintf(&buf, "got:\n%s", tCSVExcerpt(got, opts.Header, first.GotRow))
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// tCSVExcerpt renders the header and the rows around row (1-based) as an
+// aligned table, marking row with '>'.
+func tCSVExcerpt(records [][]string, header bool, row int) string {
+	var rows []int
+	if header && len(records) > 0 && row > 2 {
+		rows = append(rows, 1)
+	}
+	for r := row - 1; r <= row+1; r++ {
+		if r >= 1 && r <= len(records) {
+			rows = append(rows, r)
+		}
+	}
+
+	var widths []int
+	for _, r := range rows {
+		for i, cell := range records[r-1] {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			if n := utf8.RuneCountInString(cell); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+
+	var buf strings.Builder
+	for k, r := range rows {
+		if k > 0 && r != rows[k-1]+1 {
+			buf.WriteString("\t   ...\n")
+		}
+		mark := " "
+		if r == row {
+			mark = ">"
+		}
+		fmt.Fprintf(&buf, "\t%s%3d |", mark, r)
+		for i, cell := range records[r-1] {
+			fmt.Fprintf(&buf, " %-*s |", widths[i], cell)
+		}
+		buf.WriteString("\n")
+	}
+	return buf.String()
+}
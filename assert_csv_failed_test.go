@@ -0,0 +1,44 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// go test -assert.failed
+
+package assert
+
+import (
+	"testing"
+)
+
+func TestAssertCSVEqual_failed_01(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertCSVEqual(t,
+		[]byte("id,name,score\n1,alice,9.5\n2,bob,7\n3,carol,8\n"),
+		[]byte("id,name,score\n1,alice,9.5\n2,bob,6\n"),
+		nil,
+	)
+}
+
+func TestAssertCSVEqual_failed_02(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertCSVEqual(t,
+		[]byte("id,name,score\n1,alice,9.5\n2,bob,7\n3,carol,8\n"),
+		[]byte("name,id,score,rank\nbob,2,7.2\nalice,1,9.5\ndave,4,1\n"),
+		&CSVOptions{
+			Header:    true,
+			KeyColumn: "id",
+			Tolerance: map[string]float64{"score": 0.1},
+		},
+	)
+}
+
+func TestAssertCSVEqual_failed_missingKeyColumn(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertCSVEqual(t, []byte("id,name\n1,a\n"), []byte("name\na\n"), &CSVOptions{Header: true, KeyColumn: "id"})
+}
@@ -0,0 +1,55 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"testing"
+)
+
+func TestDiffCSV_missingKeyColumn(t *testing.T) {
+	expected, _ := tReadCSV([]byte("id,name\n1,a\n"))
+	got, _ := tReadCSV([]byte("name\na\n"))
+	diffs, err := tDiffCSV(expected, got, &CSVOptions{Header: true, KeyColumn: "id"})
+	AssertNil(t, err)
+	AssertEqual(t, []tCSVDiff{
+		{ExpectedRow: 1, Col: "id", Msg: "missing column"},
+		{ExpectedRow: 1, GotRow: 1, Col: "name", Msg: "expected at column 2, got at column 1"},
+	}, diffs)
+}
+
+func TestDiffCSV_rowWidth(t *testing.T) {
+	expected, _ := tReadCSV([]byte("id,name\n1,a\n2,\n"))
+	got, _ := tReadCSV([]byte("id,name\n1,a,extra\n2\n"))
+	for _, opts := range []*CSVOptions{{Header: true}, {Header: true, KeyColumn: "id"}} {
+		diffs, err := tDiffCSV(expected, got, opts)
+		AssertNil(t, err)
+		AssertEqual(t, []tCSVDiff{
+			{GotRow: 2, Msg: "expected 2 columns, got 3"},
+			{GotRow: 3, Msg: "expected 2 columns, got 1"},
+		}, diffs)
+	}
+
+	diffs, err := tDiffCSV(expected, got, &CSVOptions{})
+	AssertNil(t, err)
+	AssertEqual(t, []tCSVDiff{
+		{ExpectedRow: 2, GotRow: 2, Msg: "expected 2 columns, got 3"},
+		{ExpectedRow: 3, GotRow: 3, Msg: "expected 2 columns, got 1"},
+	}, diffs)
+
+	expected, _ = tReadCSV([]byte("id,name\n1\n"))
+	got, _ = tReadCSV([]byte("id,name\n1\n"))
+	diffs, err = tDiffCSV(expected, got, &CSVOptions{Header: true})
+	AssertNil(t, err)
+	AssertEqual(t, []tCSVDiff{
+		{ExpectedRow: 2, Msg: "expected 2 columns, got 1"},
+		{GotRow: 2, Msg: "expected 2 columns, got 1"},
+	}, diffs)
+}
+
+func TestCSVCell(t *testing.T) {
+	AssertEqual(t, "", tCSVCell([]string{"a"}, -1))
+	AssertEqual(t, "", tCSVCell([]string{"a"}, 1))
+	AssertEqual(t, "a", tCSVCell([]string{"a"}, 0))
+}
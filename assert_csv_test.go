@@ -0,0 +1,37 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert_test
+
+import (
+	"testing"
+
+	. "github.com/chai2010/assert"
+)
+
+func TestAssertCSVEqual(t *testing.T) {
+	AssertCSVEqual(t,
+		[]byte("id,name,score\n1,alice,9.5\n2,bob,7\n"),
+		[]byte("id,name,score\n1,alice,9.5\n2,bob,7\n"),
+		nil,
+	)
+}
+
+func TestAssertCSVEqual_options(t *testing.T) {
+	AssertCSVEqual(t,
+		[]byte("id,name,score\n1,alice,9.5\n2,bob,7\n"),
+		[]byte("score,id,name\n7.0001,2,bob\n9.4999,1,alice\n"),
+		&CSVOptions{
+			Header:            true,
+			IgnoreColumnOrder: true,
+			KeyColumn:         "id",
+			Tolerance:         map[string]float64{"score": 0.001},
+		},
+	)
+	AssertCSVEqual(t,
+		[]byte("1,alice,9.5\n"),
+		[]byte("1,alice,9.50\n"),
+		&CSVOptions{Tolerance: map[string]float64{"3": 0}},
+	)
+}
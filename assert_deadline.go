@@ -0,0 +1,99 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"testing"
+	"time"
+)
+
+// AssertCompletesWithin runs f in a new goroutine and checks that it returns
+// within timeout. On timeout the stacks of all goroutines are dumped, and f
+// is left running.
+//
+// A panic in f fails the test with its stack. If f calls tb.FailNow or
+// runtime.Goexit, the test is stopped with tb.FailNow.
+func AssertCompletesWithin(tb testing.TB, timeout time.Duration, f func(), args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	tCompletesWithin(tb, "AssertCompletesWithin", timeout, f, args)
+}
+
+// AssertCompletesWithinResult is like AssertCompletesWithin, and returns
+// the result of f.
+func AssertCompletesWithinResult[T any](tb testing.TB, timeout time.Duration, f func() T, args ...interface{}) T {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	var result T
+	tCompletesWithin(tb, "AssertCompletesWithinResult", timeout, func() { result = f() }, args)
+	return result
+}
+
+func tCompletesWithin(tb testing.TB, name string, timeout time.Duration, f func(), args []interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	type outcome struct {
+		returned bool
+		panicked bool
+		value    interface{}
+		stack    []byte
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		var out outcome
+		defer func() {
+			if !out.returned {
+				if out.value = recover(); out.value != nil {
+					out.panicked, out.stack = true, debug.Stack()
+				}
+			}
+			done <- out
+		}()
+		f()
+		out.returned = true
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case out := <-done:
+		switch {
+		case out.panicked:
+			if msg := fmt.Sprint(args...); msg != "" {
+				tb.Fatalf("%s failed, panic: %v, %s\n%s", name, out.value, msg, out.stack)
+			} else {
+				tb.Fatalf("%s failed, panic: %v\n%s", name, out.value, out.stack)
+			}
+		case !out.returned:
+			tb.FailNow()
+		}
+	case <-timer.C:
+		stacks := tAllStacks()
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("%s failed, timeout = %v, %s\n%s", name, timeout, msg, stacks)
+		} else {
+			tb.Fatalf("%s failed, timeout = %v\n%s", name, timeout, stacks)
+		}
+	}
+}
+
+// tAllStacks returns the stacks of all goroutines.
+func tAllStacks() []byte {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
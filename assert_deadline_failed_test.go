@@ -0,0 +1,40 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// go test -assert.failed
+
+package assert
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAssertCompletesWithin_failed_timeout(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	block := make(chan struct{})
+	AssertCompletesWithin(t, 10*time.Millisecond, func() {
+		<-block
+	}, "blocked on a channel")
+}
+
+func TestAssertCompletesWithin_failed_panic(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertCompletesWithinResult(t, time.Second, func() int {
+		panic("boom")
+	})
+}
+
+func TestAssertCompletesWithin_failed_failNow(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertCompletesWithin(t, time.Second, func() {
+		t.Fatal("fatal inside f")
+	})
+}
@@ -0,0 +1,25 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/chai2010/assert"
+)
+
+func TestAssertCompletesWithin(t *testing.T) {
+	AssertCompletesWithin(t, time.Second, func() {
+		time.Sleep(time.Millisecond)
+	})
+}
+
+func TestAssertCompletesWithinResult(t *testing.T) {
+	got := AssertCompletesWithinResult(t, time.Second, func() int {
+		return 42
+	})
+	AssertEqual(t, 42, got)
+}
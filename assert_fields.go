@@ -0,0 +1,153 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// AssertFieldsMatch compares got with the non-zero fields of
+// expectedPartial, which must be of the same type. Zero struct fields are
+// not compared, so a field cannot be checked for its zero value; the
+// elements of slices, arrays and maps are compared even when zero.
+//
+// The comparison recurses into pointers, interfaces, exported struct
+// fields (including those promoted through unexported embedded structs),
+// slices and arrays (which must have the same length) and maps (whose keys
+// in expectedPartial must be in got). Values with an Equal(T) bool method,
+// such as time.Time, and structs without exported fields are compared as a
+// whole. Failures are listed by field path, such as $.Items[1].Name.
+func AssertFieldsMatch(tb testing.TB, expectedPartial, got interface{}, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	if reflect.TypeOf(expectedPartial) != reflect.TypeOf(got) {
+		tb.Fatalf("AssertFieldsMatch called with different types, expected = %T, got = %T", expectedPartial, got)
+	}
+	if expectedPartial == nil {
+		return
+	}
+
+	var diffs []tTreeDiff
+	tFieldsMatch("$", reflect.ValueOf(expectedPartial), reflect.ValueOf(got), &diffs)
+	if len(diffs) != 0 {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertFieldsMatch failed, %d differences, %s\n%s", len(diffs), msg, tFormatTreeDiffs(diffs))
+		} else {
+			tb.Fatalf("AssertFieldsMatch failed, %d differences\n%s", len(diffs), tFormatTreeDiffs(diffs))
+		}
+	}
+}
+
+// tFieldsMatch compares e with g, of the same type, skipping the zero
+// fields of structs.
+func tFieldsMatch(path string, e, g reflect.Value, diffs *[]tTreeDiff) {
+	switch e.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if e.IsNil() || g.IsNil() {
+			if e.IsNil() != g.IsNil() {
+				*diffs = append(*diffs, tTreeDiff{Path: path, Expected: e.Interface(), Got: g.Interface()})
+			}
+			return
+		}
+	}
+	if eq, ok := tEqualMethod(e); ok {
+		if !eq.Call([]reflect.Value{g})[0].Bool() {
+			*diffs = append(*diffs, tTreeDiff{Path: path, Expected: e.Interface(), Got: g.Interface()})
+		}
+		return
+	}
+
+	switch e.Kind() {
+	case reflect.Ptr:
+		tFieldsMatch(path, e.Elem(), g.Elem(), diffs)
+
+	case reflect.Interface:
+		if e.Elem().Type() != g.Elem().Type() {
+			*diffs = append(*diffs, tTreeDiff{Path: path, Expected: e.Interface(), Got: g.Interface(), Reason: "type mismatch"})
+			return
+		}
+		tFieldsMatch(path, e.Elem(), g.Elem(), diffs)
+
+	case reflect.Struct:
+		if !tStructFieldsMatch(path, e, g, diffs) && !reflect.DeepEqual(e.Interface(), g.Interface()) {
+			*diffs = append(*diffs, tTreeDiff{Path: path, Expected: e.Interface(), Got: g.Interface()})
+		}
+
+	case reflect.Slice, reflect.Array:
+		if e.Len() != g.Len() {
+			*diffs = append(*diffs, tTreeDiff{Path: path, Expected: e.Len(), Got: g.Len(), Reason: "len mismatch"})
+			return
+		}
+		for i := 0; i < e.Len(); i++ {
+			tFieldsMatch(fmt.Sprintf("%s[%d]", path, i), e.Index(i), g.Index(i), diffs)
+		}
+
+	case reflect.Map:
+		keys := e.MapKeys()
+		tSortValues(keys)
+		for _, key := range keys {
+			keyPath := fmt.Sprintf("%s[%s]", path, tMatcherValue(key.Interface()))
+			gv := g.MapIndex(key)
+			if !gv.IsValid() {
+				*diffs = append(*diffs, tTreeDiff{Path: keyPath, Expected: e.MapIndex(key).Interface(), Reason: "missing"})
+				continue
+			}
+			tFieldsMatch(keyPath, e.MapIndex(key), gv, diffs)
+		}
+
+	default:
+		if !reflect.DeepEqual(e.Interface(), g.Interface()) {
+			*diffs = append(*diffs, tTreeDiff{Path: path, Expected: e.Interface(), Got: g.Interface()})
+		}
+	}
+}
+
+// tStructFieldsMatch compares the non-zero exported fields of the structs e
+// and g, and reports whether they have any.
+func tStructFieldsMatch(path string, e, g reflect.Value, diffs *[]tTreeDiff) (exported bool) {
+	for i := 0; i < e.NumField(); i++ {
+		f := e.Type().Field(i)
+		ef, gf := e.Field(i), g.Field(i)
+		switch {
+		case f.IsExported():
+			exported = true
+			if !ef.IsZero() {
+				tFieldsMatch(path+"."+f.Name, ef, gf, diffs)
+			}
+		case f.Anonymous:
+			// The exported fields of an unexported embedded struct are
+			// promoted, and can be read although the struct cannot.
+			if ef.Kind() == reflect.Ptr {
+				if ef.IsNil() {
+					continue
+				}
+				if gf.IsNil() {
+					gf = reflect.New(ef.Type().Elem())
+				}
+				ef, gf = ef.Elem(), gf.Elem()
+			}
+			if ef.Kind() == reflect.Struct && tStructFieldsMatch(path, ef, gf, diffs) {
+				exported = true
+			}
+		}
+	}
+	return exported
+}
+
+// tEqualMethod returns the Equal(T) bool method of v, of type T.
+func tEqualMethod(v reflect.Value) (reflect.Value, bool) {
+	m := v.MethodByName("Equal")
+	if !m.IsValid() {
+		return reflect.Value{}, false
+	}
+	t := m.Type()
+	if t.NumIn() != 1 || t.In(0) != v.Type() || t.NumOut() != 1 || t.Out(0).Kind() != reflect.Bool {
+		return reflect.Value{}, false
+	}
+	return m, true
+}
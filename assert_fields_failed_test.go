@@ -0,0 +1,44 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// go test -assert.failed
+
+package assert
+
+import (
+	"testing"
+)
+
+func TestAssertFieldsMatch_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	type owner struct {
+		Email string
+	}
+	type account struct {
+		ID     int
+		Owner  *owner
+		Roles  []string
+		Limits map[string]int
+	}
+	got := account{
+		ID:     1,
+		Roles:  []string{"admin"},
+		Limits: map[string]int{"cpu": 4},
+	}
+	AssertFieldsMatch(t, account{
+		ID:     2,
+		Owner:  &owner{Email: "a@example.com"},
+		Roles:  []string{"admin", "dev"},
+		Limits: map[string]int{"cpu": 8, "mem": 16},
+	}, got)
+}
+
+func TestAssertFieldsMatch_failed_type(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertFieldsMatch(t, struct{ A int }{1}, &struct{ A int }{1})
+}
@@ -0,0 +1,110 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/chai2010/assert"
+)
+
+type tAccount struct {
+	ID      int
+	Name    string
+	Active  bool
+	Created time.Time
+	Owner   *tOwner
+	Roles   []string
+	Limits  map[string]int
+	Extra   interface{}
+}
+
+type tOwner struct {
+	Email string
+	Phone string
+}
+
+type tVersion struct {
+	Major int
+}
+
+func (v *tVersion) Equal(o *tVersion) bool {
+	return v.Major == o.Major
+}
+
+type tBase struct {
+	ID int
+}
+
+type tUser struct {
+	tBase
+	*tOwner
+	Name    string
+	Version *tVersion
+}
+
+func TestAssertFieldsMatch(t *testing.T) {
+	created := time.Date(2018, 5, 1, 12, 0, 0, 0, time.UTC)
+	got := tAccount{
+		ID:      42,
+		Name:    "alice",
+		Active:  true,
+		Created: created.In(time.FixedZone("CST", 8*60*60)),
+		Owner:   &tOwner{Email: "alice@example.com", Phone: "555-0100"},
+		Roles:   []string{"admin", "dev"},
+		Limits:  map[string]int{"cpu": 4, "mem": 16},
+		Extra:   tOwner{Email: "x"},
+	}
+
+	AssertFieldsMatch(t, tAccount{Name: "alice"}, got)
+	AssertFieldsMatch(t, tAccount{
+		Created: created,
+		Owner:   &tOwner{Email: "alice@example.com"},
+		Roles:   []string{"admin", "dev"},
+		Limits:  map[string]int{"mem": 16},
+		Extra:   tOwner{Email: "x"},
+	}, got)
+	AssertFieldsMatch(t, &tAccount{ID: 42}, &got)
+}
+
+func TestAssertFieldsMatch_nested(t *testing.T) {
+	got := tAccount{
+		Roles:  []string{"admin", "dev"},
+		Limits: map[string]int{"cpu": 4, "mem": 0},
+	}
+	AssertFieldsMatch(t, tAccount{Limits: map[string]int{"mem": 0}}, got)
+
+	tb := &tCaptureTB{TB: t}
+	AssertFieldsMatch(tb, tAccount{
+		Roles:  []string{"", "dev"},
+		Limits: map[string]int{"cpu": 0},
+	}, got)
+	AssertEqual(t, []string{
+		"AssertFieldsMatch failed, 2 differences\n" +
+			"\t$.Roles[0]: expected = \"\", got = \"admin\"\n" +
+			"\t$.Limits[\"cpu\"]: expected = 0, got = 4",
+	}, tb.errors)
+}
+
+func TestAssertFieldsMatch_embedded(t *testing.T) {
+	got := tUser{
+		tBase:   tBase{ID: 1},
+		tOwner:  &tOwner{Email: "alice@example.com"},
+		Name:    "alice",
+		Version: &tVersion{Major: 2},
+	}
+	AssertFieldsMatch(t, tUser{tBase: tBase{ID: 1}, tOwner: &tOwner{Email: "alice@example.com"}}, got)
+	AssertFieldsMatch(t, tUser{Version: &tVersion{Major: 2}}, got)
+
+	tb := &tCaptureTB{TB: t}
+	AssertFieldsMatch(tb, tUser{tBase: tBase{ID: 2}, tOwner: &tOwner{Phone: "555-0100"}}, got)
+	AssertFieldsMatch(tb, tUser{Version: &tVersion{Major: 2}}, tUser{})
+	AssertFieldsMatch(tb, tUser{tOwner: &tOwner{Email: "x"}}, tUser{})
+	AssertEqual(t, 3, len(tb.errors))
+	AssertHasPrefix(t, tb.errors[0], "AssertFieldsMatch failed, 2 differences\n\t$.ID: expected = 2, got = 1\n\t$.Phone: ")
+	AssertHasPrefix(t, tb.errors[1], "AssertFieldsMatch failed, 1 differences\n\t$.Version: ")
+	AssertHasPrefix(t, tb.errors[2], "AssertFieldsMatch failed, 1 differences\n\t$.Email: ")
+}
@@ -0,0 +1,184 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// AssertNearRel checks |expected-got| <= rel * max(|expected|, |got|).
+func AssertNearRel(tb testing.TB, expected, got, rel float64, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	if !tIsClose(expected, got, rel, 0) {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertNearRel failed, expected = %v, got = %v, rel = %v, %s, %s", expected, got, rel, tFloatDistances(expected, got), msg)
+		} else {
+			tb.Fatalf("AssertNearRel failed, expected = %v, got = %v, rel = %v, %s", expected, got, rel, tFloatDistances(expected, got))
+		}
+	}
+}
+
+// AssertNearULP checks that at most ulps representable float64 values
+// lie between expected and got. +0 and -0 are 0 ULPs apart, and as for
+// AssertIsClose, infinities are only near themselves.
+func AssertNearULP(tb testing.TB, expected, got float64, ulps uint64, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	if !tNearULP(expected, got, ulps) {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertNearULP failed, expected = %v, got = %v, ulps = %v, %s, %s", expected, got, ulps, tFloatDistances(expected, got), msg)
+		} else {
+			tb.Fatalf("AssertNearULP failed, expected = %v, got = %v, ulps = %v, %s", expected, got, ulps, tFloatDistances(expected, got))
+		}
+	}
+}
+
+// AssertIsClose combines a relative and an absolute tolerance like Python's
+// math.isclose: |expected-got| <= max(rel * max(|expected|, |got|), abs).
+// Infinities are only close to themselves.
+func AssertIsClose(tb testing.TB, expected, got, rel, abs float64, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	if !tIsClose(expected, got, rel, abs) {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertIsClose failed, expected = %v, got = %v, rel = %v, abs = %v, %s, %s", expected, got, rel, abs, tFloatDistances(expected, got), msg)
+		} else {
+			tb.Fatalf("AssertIsClose failed, expected = %v, got = %v, rel = %v, abs = %v, %s", expected, got, rel, abs, tFloatDistances(expected, got))
+		}
+	}
+}
+
+func tIsClose(a, b, rel, abs float64) bool {
+	if a == b {
+		return true
+	}
+	if math.IsInf(a, 0) || math.IsInf(b, 0) {
+		return false
+	}
+	d := math.Abs(a - b)
+	return d <= rel*math.Max(math.Abs(a), math.Abs(b)) || d <= abs
+}
+
+func tNearULP(a, b float64, ulps uint64) bool {
+	if a == b {
+		return true
+	}
+	if math.IsInf(a, 0) || math.IsInf(b, 0) {
+		return false
+	}
+	d, ok := tULPDistance(a, b)
+	return ok && d <= ulps
+}
+
+// tRelDistance returns |a-b| / max(|a|, |b|), which is 0 for equal values.
+func tRelDistance(a, b float64) float64 {
+	if a == b {
+		return 0
+	}
+	return math.Abs(a-b) / math.Max(math.Abs(a), math.Abs(b))
+}
+
+// tULPDistance returns the number of float64 values between a and b,
+// or false if either is NaN.
+func tULPDistance(a, b float64) (uint64, bool) {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return 0, false
+	}
+	ia, ib := tOrderedBits(a), tOrderedBits(b)
+	if ia > ib {
+		return uint64(ia) - uint64(ib), true
+	}
+	return uint64(ib) - uint64(ia), true
+}
+
+// tOrderedBits maps a float64 to an int64 with the same ordering, with
+// consecutive floats mapping to consecutive integers and ±0 to 0.
+func tOrderedBits(f float64) int64 {
+	bits := int64(math.Float64bits(f))
+	if bits < 0 {
+		return math.MinInt64 - bits
+	}
+	return bits
+}
+
+func tFloatDistances(a, b float64) string {
+	ulps := "NaN"
+	if d, ok := tULPDistance(a, b); ok {
+		ulps = fmt.Sprint(d)
+	}
+	return fmt.Sprintf("distance = {abs: %v, rel: %v, ulps: %s}", math.Abs(a-b), tRelDistance(a, b), ulps)
+}
+
+func AssertNaN(tb testing.TB, val float64, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	if !math.IsNaN(val) {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertNaN failed, val = %v, %s", val, msg)
+		} else {
+			tb.Fatalf("AssertNaN failed, val = %v", val)
+		}
+	}
+}
+
+func AssertNotNaN(tb testing.TB, val float64, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	if math.IsNaN(val) {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertNotNaN failed, val = %v, %s", val, msg)
+		} else {
+			tb.Fatalf("AssertNotNaN failed, val = %v", val)
+		}
+	}
+}
+
+// AssertInf checks for an infinity of the given sign, as in math.IsInf:
+// +Inf if sign > 0, -Inf if sign < 0, and either if sign == 0.
+func AssertInf(tb testing.TB, val float64, sign int, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	if !math.IsInf(val, sign) {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertInf failed, val = %v, sign = %d, %s", val, sign, msg)
+		} else {
+			tb.Fatalf("AssertInf failed, val = %v, sign = %d", val, sign)
+		}
+	}
+}
+
+// AssertFinite checks that val is neither NaN nor an infinity.
+func AssertFinite(tb testing.TB, val float64, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	if math.IsNaN(val) || math.IsInf(val, 0) {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertFinite failed, val = %v, %s", val, msg)
+		} else {
+			tb.Fatalf("AssertFinite failed, val = %v", val)
+		}
+	}
+}
+
+// tNear reports |a-b| <= abs for AssertNear.
+func tNear(a, b, abs float64, nanEqual bool) bool {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return nanEqual && math.IsNaN(a) && math.IsNaN(b)
+	}
+	if a == b {
+		return true // also for equal infinities, where a-b is NaN
+	}
+	return math.Abs(a-b) <= abs
+}
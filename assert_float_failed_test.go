@@ -0,0 +1,89 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// go test -assert.failed
+
+package assert
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAssertNearRel_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertNearRel(t, 1e-12, 1.1e-12, 1e-6)
+}
+
+func TestAssertNearULP_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertNearULP(t, 1, 1+4*math.Nextafter(1, 2)-4, 2)
+}
+
+func TestAssertIsClose_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertIsClose(t, 0, 1e-9, 1e-9, 1e-12)
+}
+
+func TestAssertNaN_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertNaN(t, math.Inf(1))
+}
+
+func TestAssertNotNaN_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertNotNaN(t, math.NaN())
+}
+
+func TestAssertInf_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertInf(t, math.Inf(1), -1)
+}
+
+func TestAssertFinite_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertFinite(t, math.Inf(-1))
+}
+
+func TestAssertNear_failed_nan_01(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertNear(t, math.NaN(), math.NaN(), 1)
+}
+
+func TestAssertNear_failed_nan_02(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertNear(t, 1, math.NaN(), 1, NaNEqual)
+}
+
+func TestAssertNear_failed_inf(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertNear(t, math.Inf(1), math.MaxFloat64, math.MaxFloat64)
+}
+
+func TestAssertEqual_failed_zero(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertEqual(t, math.Copysign(0, -1), 0.0)
+}
@@ -0,0 +1,154 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type tFloatOrComplex interface {
+	~float32 | ~float64 | ~complex64 | ~complex128
+}
+
+// tMaxNearReports limits the elements listed by AssertSliceNear and
+// AssertMatrixNear.
+const tMaxNearReports = 10
+
+// AssertSliceNear compares two slices element by element with the absolute
+// tolerance tol, applied to the real and imaginary parts separately for
+// complex elements. NaN handling follows AssertNear, including NaNEqual.
+func AssertSliceNear[T tFloatOrComplex](tb testing.TB, expected, got []T, tol float64, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	opts, args := tSplitOptions(args)
+	if len(expected) != len(got) {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertSliceNear failed, len(expected) = %d, len(got) = %d, %s", len(expected), len(got), msg)
+		} else {
+			tb.Fatalf("AssertSliceNear failed, len(expected) = %d, len(got) = %d", len(expected), len(got))
+		}
+		return
+	}
+	var r tNearReport
+	for i := range expected {
+		r.check(fmt.Sprintf("[%d]", i), expected[i], got[i], tol, opts.NaNEqual)
+	}
+	if r.failed != 0 {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertSliceNear failed, tol = %v, %s, %s\n%s", tol, r.summary(len(expected)), msg, r.details())
+		} else {
+			tb.Fatalf("AssertSliceNear failed, tol = %v, %s\n%s", tol, r.summary(len(expected)), r.details())
+		}
+	}
+}
+
+// AssertMatrixNear is AssertSliceNear for matrices stored as row slices.
+func AssertMatrixNear[T tFloatOrComplex](tb testing.TB, expected, got [][]T, tol float64, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	opts, args := tSplitOptions(args)
+	shapeErr := ""
+	if len(expected) != len(got) {
+		shapeErr = fmt.Sprintf("rows(expected) = %d, rows(got) = %d", len(expected), len(got))
+	} else {
+		for i := range expected {
+			if len(expected[i]) != len(got[i]) {
+				shapeErr = fmt.Sprintf("len(expected[%d]) = %d, len(got[%d]) = %d", i, len(expected[i]), i, len(got[i]))
+				break
+			}
+		}
+	}
+	if shapeErr != "" {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertMatrixNear failed, %s, %s", shapeErr, msg)
+		} else {
+			tb.Fatalf("AssertMatrixNear failed, %s", shapeErr)
+		}
+		return
+	}
+	var r tNearReport
+	n := 0
+	for i := range expected {
+		for j := range expected[i] {
+			r.check(fmt.Sprintf("[%d][%d]", i, j), expected[i][j], got[i][j], tol, opts.NaNEqual)
+			n++
+		}
+	}
+	if r.failed != 0 {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertMatrixNear failed, tol = %v, %s, %s\n%s", tol, r.summary(n), msg, r.details())
+		} else {
+			tb.Fatalf("AssertMatrixNear failed, tol = %v, %s\n%s", tol, r.summary(n), r.details())
+		}
+	}
+}
+
+// tNearReport collects the elements out of tolerance and the largest error.
+type tNearReport struct {
+	failed   int
+	lines    []string
+	maxErr   float64
+	maxIndex string
+}
+
+func (r *tNearReport) check(index string, expected, got interface{}, tol float64, nanEqual bool) {
+	er, ei := tComponents(expected)
+	gr, gi := tComponents(got)
+
+	// The error of a complex element is the larger component error,
+	// and NaN if either component error is NaN.
+	errRe, errIm := tNearError(er, gr, nanEqual), tNearError(ei, gi, nanEqual)
+	err := math.Max(errRe, errIm)
+	if r.maxIndex == "" || !math.IsNaN(r.maxErr) && (math.IsNaN(err) || err > r.maxErr) {
+		r.maxErr, r.maxIndex = err, index
+	}
+	if tNear(er, gr, tol, nanEqual) && tNear(ei, gi, tol, nanEqual) {
+		return
+	}
+
+	r.failed++
+	if len(r.lines) < tMaxNearReports {
+		r.lines = append(r.lines, fmt.Sprintf("%s: expected = %v, got = %v, error = %v", index, expected, got, err))
+	}
+}
+
+func (r *tNearReport) summary(n int) string {
+	return fmt.Sprintf("%d of %d elements out of tolerance, max error = %v at %s", r.failed, n, r.maxErr, r.maxIndex)
+}
+
+func (r *tNearReport) details() string {
+	s := "\t" + strings.Join(r.lines, "\n\t")
+	if r.failed > len(r.lines) {
+		s += fmt.Sprintf("\n\t... and %d more", r.failed-len(r.lines))
+	}
+	return s
+}
+
+// tNearError returns |a-b|, which is 0 for equal infinities and for NaNs
+// under NaNEqual.
+func tNearError(a, b float64, nanEqual bool) float64 {
+	if a == b || nanEqual && math.IsNaN(a) && math.IsNaN(b) {
+		return 0
+	}
+	return math.Abs(a - b)
+}
+
+// tComponents returns the real and imaginary parts of a float or complex.
+func tComponents(v interface{}) (re, im float64) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Complex64, reflect.Complex128:
+		c := rv.Complex()
+		return real(c), imag(c)
+	default:
+		return rv.Float(), 0
+	}
+}
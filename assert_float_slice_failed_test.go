@@ -0,0 +1,57 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// go test -assert.failed
+
+package assert
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAssertSliceNear_failed_01(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	expected := make([]float64, 100)
+	got := make([]float64, 100)
+	for i := range got {
+		expected[i] = math.Sin(float64(i))
+		got[i] = expected[i] + float64(i%7)*0.001
+	}
+	AssertSliceNear(t, expected, got, 0.0025)
+}
+
+func TestAssertSliceNear_failed_02(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertSliceNear(t, []complex128{1 + 2i, 3i}, []complex128{1 + 2.1i, 3i}, 0.01)
+}
+
+func TestAssertSliceNear_failed_03(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertSliceNear(t, []float32{1, 2}, []float32{1, 2, 3}, 0.01)
+}
+
+func TestAssertMatrixNear_failed_01(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertMatrixNear(t,
+		[][]float64{{1, 0}, {0, 1}},
+		[][]float64{{1, 0.5}, {math.NaN(), 1}},
+		0.01,
+	)
+}
+
+func TestAssertMatrixNear_failed_02(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertMatrixNear(t, [][]float64{{1, 0}, {0, 1}}, [][]float64{{1, 0}, {0}}, 0.01)
+}
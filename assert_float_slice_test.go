@@ -0,0 +1,30 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/chai2010/assert"
+)
+
+func TestAssertSliceNear(t *testing.T) {
+	AssertSliceNear(t, []float64{1, 2, 3}, []float64{1.001, 1.999, 3}, 0.01)
+	AssertSliceNear(t, []float32{0.1, 0.2}, []float32{0.1000001, 0.2}, 1e-6)
+	AssertSliceNear(t, []complex128{1 + 2i, 3i}, []complex128{1.001 + 1.999i, 0.001 + 3i}, 0.01)
+	AssertSliceNear(t, []complex64{1i}, []complex64{1i}, 0)
+	AssertSliceNear(t, []float64{math.NaN(), math.Inf(1)}, []float64{math.NaN(), math.Inf(1)}, 0, NaNEqual)
+	AssertSliceNear(t, []float64{}, nil, 0)
+}
+
+func TestAssertMatrixNear(t *testing.T) {
+	AssertMatrixNear(t,
+		[][]float64{{1, 0}, {0, 1}},
+		[][]float64{{0.999, 1e-9}, {-1e-9, 1.001}},
+		0.01,
+	)
+	AssertMatrixNear(t, [][]complex128{{1i, 2}}, [][]complex128{{1.0001i, 2}}, 0.001)
+}
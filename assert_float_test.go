@@ -0,0 +1,79 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/chai2010/assert"
+)
+
+func TestAssertNearRel(t *testing.T) {
+	AssertNearRel(t, 1e12, 1e12+1, 1e-9)
+	AssertNearRel(t, 1e-12, 1.0000001e-12, 1e-6)
+	AssertNearRel(t, 0, 0, 0)
+}
+
+func TestAssertNearULP(t *testing.T) {
+	AssertNearULP(t, 1, math.Nextafter(1, 2), 1)
+	AssertNearULP(t, 0.1+0.2, 0.3, 1)
+	AssertNearULP(t, math.Copysign(0, -1), 0, 0)
+	AssertNearULP(t, -math.SmallestNonzeroFloat64, math.SmallestNonzeroFloat64, 2)
+	AssertNearULP(t, math.Inf(1), math.Inf(1), 0)
+
+	tb := &tCaptureTB{TB: t}
+	AssertNearULP(tb, math.MaxFloat64, math.Inf(1), 1)
+	AssertEqual(t, 1, len(tb.errors))
+}
+
+func TestAssertIsClose(t *testing.T) {
+	AssertIsClose(t, 1e12, 1e12+1, 1e-9, 0)
+	AssertIsClose(t, 0, 1e-15, 1e-9, 1e-12)
+	AssertIsClose(t, math.Inf(1), math.Inf(1), 0, 0)
+}
+
+func TestAssertNaN(t *testing.T) {
+	AssertNaN(t, math.NaN())
+	AssertNaN(t, math.Inf(1)-math.Inf(1))
+}
+
+func TestAssertNotNaN(t *testing.T) {
+	AssertNotNaN(t, math.Inf(-1))
+	AssertNotNaN(t, math.Copysign(0, -1))
+}
+
+func TestAssertInf(t *testing.T) {
+	AssertInf(t, math.Inf(1), 1)
+	AssertInf(t, math.Inf(-1), -1)
+	AssertInf(t, math.Inf(-1), 0)
+
+	max := math.MaxFloat64
+	AssertInf(t, max*2, 1)
+}
+
+func TestAssertFinite(t *testing.T) {
+	AssertFinite(t, math.MaxFloat64)
+	AssertFinite(t, math.Copysign(0, -1))
+}
+
+func TestAssertNear_special(t *testing.T) {
+	AssertNear(t, math.NaN(), math.NaN(), 0, NaNEqual)
+	AssertNear(t, math.NaN(), math.NaN(), 0, "message", NaNEqual)
+	AssertNear(t, math.Inf(1), math.Inf(1), 0)
+	AssertNear(t, math.Copysign(0, -1), 0, 0)
+}
+
+func TestAssertEqual_special(t *testing.T) {
+	AssertEqual(t, math.NaN(), math.NaN())
+	AssertEqual(t, math.NaN(), math.NaN(), NaNEqual)
+	AssertEqual(t, []float64{1, math.NaN()}, []float64{1, math.NaN()})
+	AssertNotEqual(t, math.Copysign(0, -1), 0.0)
+	AssertNotEqual(t, 1.0, math.NaN(), NaNEqual)
+
+	tb := &tCaptureTB{TB: t}
+	AssertNotEqual(tb, math.NaN(), math.NaN(), NaNEqual)
+	AssertEqual(t, []string{"AssertNotEqual failed, expected = NaN, got = NaN"}, tb.errors)
+}
@@ -0,0 +1,256 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Chain is a chain of checks on one value, started by Check. Each check
+// runs the Assert* function named in its doc, so it passes and fails like
+// that function. The chain stops at the first failed check, whose message
+// describes the whole chain up to it:
+//
+//	assert.Check(t, names).IsNotNil().HasLen(3).Contains("x")
+//	assert.Check(t, err).IsError().Is(io.EOF)
+type Chain struct {
+	tb    testing.TB
+	got   interface{}
+	args  []interface{}
+	steps []string
+
+	failed bool
+}
+
+// Check starts a chain of checks on got. The optional args are added to the
+// failure message.
+func Check(tb testing.TB, got interface{}, args ...interface{}) *Chain {
+	return &Chain{tb: tb, got: got, args: args}
+}
+
+// Failed reports whether a check of the chain has failed.
+func (c *Chain) Failed() bool {
+	return c.failed
+}
+
+// IsNil checks the value with AssertNil.
+func (c *Chain) IsNil() *Chain {
+	c.tb.Helper()
+	return c.run("IsNil()", func(tb testing.TB) {
+		AssertNil(tb, c.got, c.args...)
+	})
+}
+
+// IsNotNil checks the value with AssertNotNil.
+func (c *Chain) IsNotNil() *Chain {
+	c.tb.Helper()
+	return c.run("IsNotNil()", func(tb testing.TB) {
+		AssertNotNil(tb, c.got, c.args...)
+	})
+}
+
+// IsTrue checks a bool value with AssertTrue.
+func (c *Chain) IsTrue() *Chain {
+	c.tb.Helper()
+	return c.run("IsTrue()", func(tb testing.TB) {
+		AssertTrue(tb, c.bool(tb, "IsTrue"), c.args...)
+	})
+}
+
+// IsFalse checks a bool value with AssertFalse.
+func (c *Chain) IsFalse() *Chain {
+	c.tb.Helper()
+	return c.run("IsFalse()", func(tb testing.TB) {
+		AssertFalse(tb, c.bool(tb, "IsFalse"), c.args...)
+	})
+}
+
+// Equals checks the value with AssertEqual.
+func (c *Chain) Equals(expected interface{}) *Chain {
+	c.tb.Helper()
+	return c.run(fmt.Sprintf("Equals(%s)", tTruncatedView(expected)), func(tb testing.TB) {
+		AssertEqual(tb, expected, c.got, c.args...)
+	})
+}
+
+// IsZero checks the value with AssertZero.
+func (c *Chain) IsZero() *Chain {
+	c.tb.Helper()
+	return c.run("IsZero()", func(tb testing.TB) {
+		AssertZero(tb, c.got, c.args...)
+	})
+}
+
+// IsNotZero checks the value with AssertNotZero.
+func (c *Chain) IsNotZero() *Chain {
+	c.tb.Helper()
+	return c.run("IsNotZero()", func(tb testing.TB) {
+		AssertNotZero(tb, c.got, c.args...)
+	})
+}
+
+// HasLen checks the value with AssertLen.
+func (c *Chain) HasLen(n int) *Chain {
+	c.tb.Helper()
+	return c.run(fmt.Sprintf("HasLen(%d)", n), func(tb testing.TB) {
+		AssertLen(tb, c.got, n, c.args...)
+	})
+}
+
+// IsEmpty checks the value with AssertEmpty.
+func (c *Chain) IsEmpty() *Chain {
+	c.tb.Helper()
+	return c.run("IsEmpty()", func(tb testing.TB) {
+		AssertEmpty(tb, c.got, c.args...)
+	})
+}
+
+// IsNotEmpty checks the value with AssertNotEmpty.
+func (c *Chain) IsNotEmpty() *Chain {
+	c.tb.Helper()
+	return c.run("IsNotEmpty()", func(tb testing.TB) {
+		AssertNotEmpty(tb, c.got, c.args...)
+	})
+}
+
+// Contains checks for a substring of a string, []byte or fmt.Stringer with
+// AssertContains, or else for an element of a slice or array, or a key of
+// a map, with AssertSuperset.
+func (c *Chain) Contains(v interface{}) *Chain {
+	c.tb.Helper()
+	return c.run(fmt.Sprintf("Contains(%s)", tTruncatedView(v)), func(tb testing.TB) {
+		if substr, ok := v.(string); ok {
+			if _, ok := tStringOf(c.got); ok {
+				AssertContains(tb, c.got, substr, c.args...)
+				return
+			}
+		}
+		AssertSuperset(tb, []interface{}{v}, c.got, c.args...)
+	})
+}
+
+// Matches checks a string, []byte or fmt.Stringer with AssertMatchString.
+func (c *Chain) Matches(pattern string) *Chain {
+	c.tb.Helper()
+	return c.run(fmt.Sprintf("Matches(%q)", pattern), func(tb testing.TB) {
+		s, ok := tStringOf(c.got)
+		if !ok {
+			tb.Fatalf("Matches called with non-string value of type %T", c.got)
+		}
+		AssertMatchString(tb, pattern, s, c.args...)
+	})
+}
+
+// IsError checks that the value is a non-nil error.
+func (c *Chain) IsError() *Chain {
+	c.tb.Helper()
+	return c.run("IsError()", func(tb testing.TB) {
+		c.error(tb, "IsError")
+	})
+}
+
+// Is checks that the value is an error matching target with errors.Is.
+func (c *Chain) Is(target error) *Chain {
+	c.tb.Helper()
+	return c.run(fmt.Sprintf("Is(%v)", target), func(tb testing.TB) {
+		if err := c.error(tb, "Is"); !errors.Is(err, target) {
+			c.fatalf(tb, "Is failed, err = %v", err)
+		}
+	})
+}
+
+// ErrorContains checks that the value is an error whose message contains
+// substr, with AssertContains.
+func (c *Chain) ErrorContains(substr string) *Chain {
+	c.tb.Helper()
+	return c.run(fmt.Sprintf("ErrorContains(%q)", substr), func(tb testing.TB) {
+		AssertContains(tb, c.error(tb, "ErrorContains").Error(), substr, c.args...)
+	})
+}
+
+// run adds a check to the chain and runs it, unless the chain has failed.
+// check is given a testing.TB that stops it at the first failure, which
+// then fails the chain.
+func (c *Chain) run(step string, check func(tb testing.TB)) *Chain {
+	c.tb.Helper()
+	if c.failed {
+		return c
+	}
+	c.steps = append(c.steps, step)
+
+	rec := &tChainTB{TB: c.tb}
+	func() {
+		defer tRecoverStop()
+		check(&tExpectTB{TB: rec})
+	}()
+	if rec.failed {
+		if rec.msg == "" {
+			rec.msg = "failed"
+		}
+		c.failed = true
+		c.tb.Fatalf("Check(...).%s: %s", strings.Join(c.steps, "."), rec.msg)
+	}
+	return c
+}
+
+// bool returns the value as a bool, failing the check if it is not one.
+func (c *Chain) bool(tb testing.TB, name string) bool {
+	b, ok := c.got.(bool)
+	if !ok {
+		tb.Fatalf("%s called with non-bool value of type %T", name, c.got)
+	}
+	return b
+}
+
+// error returns the value as a non-nil error, failing the check if it is
+// not one.
+func (c *Chain) error(tb testing.TB, name string) error {
+	err, ok := c.got.(error)
+	if !ok || err == nil {
+		c.fatalf(tb, "%s failed, not an error, got = %s", name, tTruncatedView(c.got))
+	}
+	return err
+}
+
+// fatalf fails a check that has no Assert* function, adding the args of
+// the chain to the message like the Assert* functions do.
+func (c *Chain) fatalf(tb testing.TB, format string, args ...interface{}) {
+	if msg := fmt.Sprint(c.args...); msg != "" {
+		tb.Fatalf("%s, %s", fmt.Sprintf(format, args...), msg)
+	} else {
+		tb.Fatalf(format, args...)
+	}
+}
+
+// tChainTB keeps the failure of a check of a Chain, for the chain to
+// report. It is wrapped in a tExpectTB, which stops the check.
+type tChainTB struct {
+	testing.TB
+
+	failed bool
+	msg    string
+}
+
+func (x *tChainTB) Fail() {
+	x.failed = true
+}
+
+func (x *tChainTB) Error(args ...interface{}) {
+	x.Errorf("%s", tSprintln(args...))
+}
+
+func (x *tChainTB) Errorf(format string, args ...interface{}) {
+	if !x.failed || x.msg == "" {
+		x.msg = fmt.Sprintf(format, args...)
+	}
+	x.failed = true
+}
+
+func (x *tChainTB) Failed() bool {
+	return x.failed || x.TB.Failed()
+}
@@ -0,0 +1,27 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// go test -assert.failed
+
+package assert
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestCheck_failed_01(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	Check(t, []string{"a", "b", "c"}).IsNotNil().HasLen(3).Contains("x")
+}
+
+func TestCheck_failed_02(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	Check(t, errors.New("closed"), "reading body").IsError().Is(io.EOF)
+}
@@ -0,0 +1,68 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert_test
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"testing"
+
+	. "github.com/chai2010/assert"
+)
+
+func TestCheck(t *testing.T) {
+	Check(t, []string{"x", "y", "z"}).IsNotNil().HasLen(3).Contains("x").IsNotEmpty()
+	Check(t, map[string]int{"a": 1}).HasLen(1).Contains("a")
+	Check(t, [2]int{1, 2}).Contains(2)
+	Check(t, "hello, world").Contains("world").Matches(`^hello`).Equals("hello, world")
+	Check(t, (*int)(nil)).IsNotNil().IsZero()
+	Check(t, nil).IsNil().IsZero()
+	Check(t, 0).IsZero()
+	Check(t, true).IsTrue()
+	Check(t, "").IsEmpty()
+
+	err := fmt.Errorf("read config: %w", io.EOF)
+	Check(t, err).IsError().Is(io.EOF).ErrorContains("config")
+}
+
+func TestCheck_stop(t *testing.T) {
+	tb := &tCaptureTB{TB: t}
+	c := Check(tb, []int{1, 2}).IsNotNil().HasLen(3).Contains(1)
+	AssertTrue(t, c.Failed())
+	AssertEqual(t, []string{
+		"Check(...).IsNotNil().HasLen(3): AssertLen failed, expected = 3, got = 2, obj = [1 2]",
+	}, tb.errors)
+}
+
+// TestCheck_sameAsAssert checks that the steps fail like the Assert*
+// functions they run.
+func TestCheck_sameAsAssert(t *testing.T) {
+	for _, tt := range []struct {
+		chain  func(tb testing.TB) *Chain
+		assert func(tb testing.TB)
+	}{
+		{
+			func(tb testing.TB) *Chain { return Check(tb, (*int)(nil)).IsNil() },
+			func(tb testing.TB) { AssertNil(tb, (*int)(nil)) },
+		},
+		{
+			func(tb testing.TB) *Chain { return Check(tb, "a\nb\n", "msg").Equals("a\nc\n") },
+			func(tb testing.TB) { AssertEqual(tb, "a\nc\n", "a\nb\n", "msg") },
+		},
+		{
+			func(tb testing.TB) *Chain { return Check(tb, math.NaN(), NaNEqual).Equals(1.0) },
+			func(tb testing.TB) { AssertEqual(tb, 1.0, math.NaN(), NaNEqual) },
+		},
+	} {
+		chainTB, assertTB := &tCaptureTB{TB: t}, &tCaptureTB{TB: t}
+		c := tt.chain(chainTB)
+		tt.assert(assertTB)
+		AssertTrue(t, c.Failed())
+		AssertEqual(t, 1, len(chainTB.errors))
+		AssertEqual(t, 1, len(assertTB.errors))
+		AssertHasSuffix(t, chainTB.errors[0], ": "+assertTB.errors[0])
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"strings"
+	"testing"
+)
+
+// The AssertHTTP* functions accept as target either a handler
+// (http.Handler or func(http.ResponseWriter, *http.Request)) served with req
+// through an httptest.ResponseRecorder, or an already recorded response
+// (*http.Response or *httptest.ResponseRecorder), in which case req may be
+// nil. Failures dump the full request and response.
+
+func AssertHTTPStatus(tb testing.TB, target interface{}, req *http.Request, code int, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	ex, err := tHTTPDo(target, req)
+	if err != nil {
+		tb.Fatalf("AssertHTTPStatus called with %v", err)
+	}
+	if ex.Response.StatusCode != code {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertHTTPStatus failed, expected = %d, got = %d, %s\n%s", code, ex.Response.StatusCode, msg, ex)
+		} else {
+			tb.Fatalf("AssertHTTPStatus failed, expected = %d, got = %d\n%s", code, ex.Response.StatusCode, ex)
+		}
+	}
+}
+
+// AssertHTTPHeader checks the response header key. A header with several
+// values is compared as the values joined with ", ", the way HTTP combines
+// repeated header fields.
+func AssertHTTPHeader(tb testing.TB, target interface{}, req *http.Request, key, value string, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	ex, err := tHTTPDo(target, req)
+	if err != nil {
+		tb.Fatalf("AssertHTTPHeader called with %v", err)
+	}
+	values, ok := ex.Response.Header[http.CanonicalHeaderKey(key)]
+	if v := strings.Join(values, ", "); !ok || v != value {
+		got := "<missing>"
+		if ok {
+			got = fmt.Sprintf("%q", v)
+		}
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertHTTPHeader failed, key = %s, expected = %q, got = %s, %s\n%s", key, value, got, msg, ex)
+		} else {
+			tb.Fatalf("AssertHTTPHeader failed, key = %s, expected = %q, got = %s\n%s", key, value, got, ex)
+		}
+	}
+}
+
+func AssertHTTPBodyContains(tb testing.TB, target interface{}, req *http.Request, substr string, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	ex, err := tHTTPDo(target, req)
+	if err != nil {
+		tb.Fatalf("AssertHTTPBodyContains called with %v", err)
+	}
+	if !bytes.Contains(ex.Body, []byte(substr)) {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertHTTPBodyContains failed, substr = %q, %s\n%s", substr, msg, ex)
+		} else {
+			tb.Fatalf("AssertHTTPBodyContains failed, substr = %q\n%s", substr, ex)
+		}
+	}
+}
+
+// AssertHTTPBodyJSON compares the response body with expected, which is
+// either JSON text (string or []byte) or a value encoded with encoding/json.
+// Both sides are compared as decoded trees, like AssertStructuredEqual.
+func AssertHTTPBodyJSON(tb testing.TB, target interface{}, req *http.Request, expected interface{}, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	ex, err := tHTTPDo(target, req)
+	if err != nil {
+		tb.Fatalf("AssertHTTPBodyJSON called with %v", err)
+	}
+	expectedTree, err := tJSONTree(expected)
+	if err != nil {
+		tb.Fatalf("AssertHTTPBodyJSON called with invalid expected value, err = %v", err)
+	}
+	gotTree, err := FormatJSON.Decode(ex.Body)
+	if err != nil {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertHTTPBodyJSON failed, err = %v, %s\n%s", err, msg, ex)
+		} else {
+			tb.Fatalf("AssertHTTPBodyJSON failed, err = %v\n%s", err, ex)
+		}
+		return
+	}
+	if diffs := tDiffTree(expectedTree, gotTree); len(diffs) != 0 {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertHTTPBodyJSON failed, %s\n%s\n%s", msg, tFormatTreeDiffs(diffs), ex)
+		} else {
+			tb.Fatalf("AssertHTTPBodyJSON failed\n%s\n%s", tFormatTreeDiffs(diffs), ex)
+		}
+	}
+}
+
+// AssertHTTPRedirect checks for a 3xx status and, if location is not empty,
+// for that Location header.
+func AssertHTTPRedirect(tb testing.TB, target interface{}, req *http.Request, location string, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	ex, err := tHTTPDo(target, req)
+	if err != nil {
+		tb.Fatalf("AssertHTTPRedirect called with %v", err)
+	}
+	code, got := ex.Response.StatusCode, ex.Response.Header.Get("Location")
+	if code < 300 || code > 399 || (location != "" && got != location) {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertHTTPRedirect failed, expected = %q, got = %d %q, %s\n%s", location, code, got, msg, ex)
+		} else {
+			tb.Fatalf("AssertHTTPRedirect failed, expected = %q, got = %d %q\n%s", location, code, got, ex)
+		}
+	}
+}
+
+// tHTTPExchange is a request and its response, with the body read.
+type tHTTPExchange struct {
+	Request  *http.Request
+	Response *http.Response
+	Body     []byte
+
+	requestDump string
+}
+
+func (ex *tHTTPExchange) String() string {
+	var buf strings.Builder
+	buf.WriteString("--- request\n")
+	if ex.requestDump != "" {
+		buf.WriteString(strings.TrimRight(ex.requestDump, "\r\n"))
+	} else {
+		buf.WriteString("<none>")
+	}
+	buf.WriteString("\n--- response\n")
+	ex.Response.Body = io.NopCloser(bytes.NewReader(ex.Body))
+	if dump, err := httputil.DumpResponse(ex.Response, true); err == nil {
+		buf.Write(dump)
+	} else {
+		fmt.Fprintf(&buf, "<%v>", err)
+	}
+	return strings.TrimRight(buf.String(), "\r\n")
+}
+
+func tHTTPDo(target interface{}, req *http.Request) (*tHTTPExchange, error) {
+	var handler http.Handler
+	switch x := target.(type) {
+	case *http.Response:
+		if x == nil {
+			return nil, fmt.Errorf("nil *http.Response")
+		}
+		if req == nil {
+			req = x.Request
+		}
+		return tHTTPRecorded(x, req)
+	case *httptest.ResponseRecorder:
+		if x == nil {
+			return nil, fmt.Errorf("nil *httptest.ResponseRecorder")
+		}
+		return tHTTPRecorded(x.Result(), req)
+	case http.Handler:
+		handler = x
+	case func(http.ResponseWriter, *http.Request):
+		handler = http.HandlerFunc(x)
+	default:
+		return nil, fmt.Errorf("non-handler value of type %T", target)
+	}
+	if req == nil {
+		return nil, fmt.Errorf("nil request")
+	}
+
+	ex := &tHTTPExchange{Request: req}
+	if dump, err := httputil.DumpRequest(req, true); err == nil {
+		ex.requestDump = string(dump)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	ex.Response = rec.Result()
+	ex.Response.Request = req
+	ex.Body = rec.Body.Bytes()
+	return ex, nil
+}
+
+func tHTTPRecorded(resp *http.Response, req *http.Request) (*tHTTPExchange, error) {
+	ex := &tHTTPExchange{Request: req, Response: resp}
+	if resp.Body != nil {
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("unreadable response body, err = %v", err)
+		}
+		ex.Body = body
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(ex.Body))
+
+	if req != nil {
+		// The body of a client request has usually been sent already.
+		withBody := req.GetBody != nil
+		if withBody {
+			if body, err := req.GetBody(); err == nil {
+				req.Body = body
+			} else {
+				withBody = false
+			}
+		}
+		if dump, err := httputil.DumpRequest(req, withBody); err == nil {
+			ex.requestDump = string(dump)
+		}
+	}
+	return ex, nil
+}
+
+// tJSONTree decodes expected JSON text, or encodes and decodes a Go value,
+// into the generic tree used by tDiffTree.
+func tJSONTree(v interface{}) (interface{}, error) {
+	switch x := v.(type) {
+	case string:
+		return FormatJSON.Decode([]byte(x))
+	case []byte:
+		return FormatJSON.Decode(x)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return FormatJSON.Decode(data)
+}
@@ -0,0 +1,56 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// go test -assert.failed
+
+package assert
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func tHelloHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintln(w, `{"hello": "world", "n": 1}`)
+}
+
+func TestAssertHTTPStatus_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	req := httptest.NewRequest("POST", "/hello", strings.NewReader(`{"name": "x"}`))
+	AssertHTTPStatus(t, tHelloHandler, req, http.StatusCreated)
+}
+
+func TestAssertHTTPHeader_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertHTTPHeader(t, tHelloHandler, httptest.NewRequest("GET", "/hello", nil), "Content-Type", "text/plain")
+}
+
+func TestAssertHTTPBodyContains_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertHTTPBodyContains(t, tHelloHandler, httptest.NewRequest("GET", "/hello", nil), "goodbye")
+}
+
+func TestAssertHTTPBodyJSON_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertHTTPBodyJSON(t, tHelloHandler, httptest.NewRequest("GET", "/hello", nil), `{"hello": "gopher", "n": 1}`)
+}
+
+func TestAssertHTTPRedirect_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertHTTPRedirect(t, tHelloHandler, httptest.NewRequest("GET", "/hello", nil), "/login")
+}
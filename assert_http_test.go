@@ -0,0 +1,84 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/chai2010/assert"
+)
+
+func tItemsHandler(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/old":
+		http.Redirect(w, r, "/v1/items", http.StatusMovedPermanently)
+	case r.Method == "POST":
+		var item map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		item["id"] = 7
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(item)
+	default:
+		fmt.Fprintln(w, "items: 0")
+	}
+}
+
+func TestAssertHTTPStatus(t *testing.T) {
+	AssertHTTPStatus(t, http.HandlerFunc(tItemsHandler), httptest.NewRequest("GET", "/v1/items", nil), http.StatusOK)
+	AssertHTTPStatus(t, tItemsHandler, httptest.NewRequest("POST", "/v1/items", strings.NewReader(`{}`)), http.StatusCreated)
+}
+
+func TestAssertHTTPHeader(t *testing.T) {
+	req := httptest.NewRequest("POST", "/v1/items", strings.NewReader(`{"name": "x"}`))
+	AssertHTTPHeader(t, tItemsHandler, req, "content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	rec.Header().Add("Vary", "Accept")
+	rec.Header().Add("Vary", "Origin")
+	AssertHTTPHeader(t, rec, nil, "Vary", "Accept, Origin")
+
+	tb := &tCaptureTB{TB: t}
+	AssertHTTPHeader(tb, rec, nil, "Vary", "Accept")
+	AssertEqual(t, 1, len(tb.errors))
+}
+
+func TestAssertHTTPBodyContains(t *testing.T) {
+	AssertHTTPBodyContains(t, tItemsHandler, httptest.NewRequest("GET", "/v1/items", nil), "items: 0")
+}
+
+func TestAssertHTTPBodyJSON(t *testing.T) {
+	req := httptest.NewRequest("POST", "/v1/items", strings.NewReader(`{"name": "x"}`))
+	AssertHTTPBodyJSON(t, tItemsHandler, req, `{"id": 7, "name": "x"}`)
+
+	req = httptest.NewRequest("POST", "/v1/items", strings.NewReader(`{"name": "x"}`))
+	AssertHTTPBodyJSON(t, tItemsHandler, req, map[string]interface{}{"id": 7, "name": "x"})
+}
+
+func TestAssertHTTPRedirect(t *testing.T) {
+	AssertHTTPRedirect(t, tItemsHandler, httptest.NewRequest("GET", "/old", nil), "/v1/items")
+}
+
+func TestAssertHTTP_response(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(tItemsHandler))
+	defer ts.Close()
+
+	resp, err := http.Post(ts.URL+"/v1/items", "application/json", strings.NewReader(`{"name": "x"}`))
+	AssertNil(t, err)
+	AssertHTTPStatus(t, resp, nil, http.StatusCreated)
+	AssertHTTPBodyJSON(t, resp, nil, `{"id": 7, "name": "x"}`)
+
+	rec := httptest.NewRecorder()
+	tItemsHandler(rec, httptest.NewRequest("GET", "/v1/items", nil))
+	AssertHTTPBodyContains(t, rec, nil, "items")
+}
@@ -0,0 +1,158 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"unicode/utf8"
+)
+
+// The length assertions accept strings, slices, arrays, maps, channels and
+// values with a Len() int method; nil, or a nil pointer with such a method,
+// counts as empty. AssertCap accepts slices, arrays, channels and values
+// with a Cap() int method.
+
+func AssertLen(tb testing.TB, obj interface{}, n int, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	l, ok := tLen(obj)
+	if !ok {
+		tb.Fatalf("AssertLen called with value of type %T without length", obj)
+	}
+	if l != n {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertLen failed, expected = %d, got = %d, obj = %s, %s", n, l, tTruncatedView(obj), msg)
+		} else {
+			tb.Fatalf("AssertLen failed, expected = %d, got = %d, obj = %s", n, l, tTruncatedView(obj))
+		}
+	}
+}
+
+func AssertEmpty(tb testing.TB, obj interface{}, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	l, ok := tLen(obj)
+	if !ok {
+		tb.Fatalf("AssertEmpty called with value of type %T without length", obj)
+	}
+	if l != 0 {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertEmpty failed, len = %d, obj = %s, %s", l, tTruncatedView(obj), msg)
+		} else {
+			tb.Fatalf("AssertEmpty failed, len = %d, obj = %s", l, tTruncatedView(obj))
+		}
+	}
+}
+
+func AssertNotEmpty(tb testing.TB, obj interface{}, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	l, ok := tLen(obj)
+	if !ok {
+		tb.Fatalf("AssertNotEmpty called with value of type %T without length", obj)
+	}
+	if l == 0 {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertNotEmpty failed, obj = %s, %s", tTruncatedView(obj), msg)
+		} else {
+			tb.Fatalf("AssertNotEmpty failed, obj = %s", tTruncatedView(obj))
+		}
+	}
+}
+
+func AssertCap(tb testing.TB, obj interface{}, n int, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	c, ok := tCap(obj)
+	if !ok {
+		tb.Fatalf("AssertCap called with value of type %T without capacity", obj)
+	}
+	if c != n {
+		l, _ := tLen(obj)
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertCap failed, expected = %d, got = %d, len = %d, obj = %s, %s", n, c, l, tTruncatedView(obj), msg)
+		} else {
+			tb.Fatalf("AssertCap failed, expected = %d, got = %d, len = %d, obj = %s", n, c, l, tTruncatedView(obj))
+		}
+	}
+}
+
+func tLen(obj interface{}) (int, bool) {
+	if obj == nil {
+		return 0, true
+	}
+	v := reflect.ValueOf(obj)
+	if x, ok := obj.(interface{ Len() int }); ok {
+		if v.Kind() == reflect.Ptr && v.IsNil() {
+			return 0, true
+		}
+		return x.Len(), true
+	}
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+		return v.Len(), true
+	case reflect.Ptr:
+		if v.Elem().Kind() == reflect.Array {
+			return v.Elem().Len(), true
+		}
+	}
+	return 0, false
+}
+
+func tCap(obj interface{}) (int, bool) {
+	v := reflect.ValueOf(obj)
+	if x, ok := obj.(interface{ Cap() int }); ok {
+		if v.Kind() == reflect.Ptr && v.IsNil() {
+			return 0, true
+		}
+		return x.Cap(), true
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Chan:
+		return v.Cap(), true
+	case reflect.Ptr:
+		if v.Elem().Kind() == reflect.Array {
+			return v.Elem().Cap(), true
+		}
+	}
+	return 0, false
+}
+
+// tMaxViewLen is the number of characters tTruncatedView keeps.
+const tMaxViewLen = 100
+
+// tTruncatedView formats obj with %v (%q for strings), cut after
+// tMaxViewLen characters.
+func tTruncatedView(obj interface{}) string {
+	var s string
+	switch x := obj.(type) {
+	case string:
+		if len(x) > tMaxViewLen*utf8.UTFMax {
+			x = x[:tMaxViewLen*utf8.UTFMax]
+		}
+		s = fmt.Sprintf("%q", x)
+	case []byte:
+		s = fmt.Sprintf("%q", x)
+	default:
+		s = fmt.Sprintf("%v", obj)
+	}
+	if utf8.RuneCountInString(s) <= tMaxViewLen {
+		return s
+	}
+	n := 0
+	for i := range s {
+		if n == tMaxViewLen {
+			return s[:i] + "..."
+		}
+		n++
+	}
+	return s
+}
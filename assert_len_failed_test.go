@@ -0,0 +1,47 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// go test -assert.failed
+
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAssertLen_failed_01(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertLen(t, []int{1, 2, 3, 4}, 3)
+}
+
+func TestAssertLen_failed_02(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertLen(t, strings.Repeat("abcdefghij", 20), 100)
+}
+
+func TestAssertEmpty_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertEmpty(t, map[string]int{"a": 1, "b": 2})
+}
+
+func TestAssertNotEmpty_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertNotEmpty(t, []string{})
+}
+
+func TestAssertCap_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertCap(t, make([]int, 2, 4), 2)
+}
@@ -0,0 +1,49 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert_test
+
+import (
+	"bytes"
+	"container/list"
+	"strings"
+	"testing"
+
+	. "github.com/chai2010/assert"
+)
+
+func TestAssertLen(t *testing.T) {
+	AssertLen(t, "abc", 3)
+	AssertLen(t, []int{1, 2}, 2)
+	AssertLen(t, [4]int{}, 4)
+	AssertLen(t, map[string]int{"a": 1}, 1)
+	AssertLen(t, make(chan int, 3), 0)
+	AssertLen(t, bytes.NewBufferString("hello"), 5)
+
+	l := list.New()
+	l.PushBack(1)
+	AssertLen(t, l, 1)
+}
+
+func TestAssertEmpty(t *testing.T) {
+	AssertEmpty(t, "")
+	AssertEmpty(t, []int(nil))
+	AssertEmpty(t, map[string]int{})
+	AssertEmpty(t, nil)
+	AssertEmpty(t, strings.NewReader(""))
+	AssertEmpty(t, (*bytes.Buffer)(nil))
+}
+
+func TestAssertNotEmpty(t *testing.T) {
+	AssertNotEmpty(t, "a")
+	AssertNotEmpty(t, [1]int{})
+	AssertNotEmpty(t, map[string]int{"a": 1})
+}
+
+func TestAssertCap(t *testing.T) {
+	AssertCap(t, make([]int, 1, 8), 8)
+	AssertCap(t, make(chan int, 3), 3)
+	AssertCap(t, bytes.NewBuffer(make([]byte, 0, 16)), 16)
+	AssertCap(t, (*bytes.Buffer)(nil), 0)
+}
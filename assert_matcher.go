@@ -0,0 +1,439 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Matcher is a composable condition on a value, checked by AssertThat.
+//
+// Describe tells what the matcher expects, such as "equal to 3", and
+// DescribeMismatch tells why v does not match, such as "was 4". Matchers
+// that look into a value prefix the mismatch of the inner matcher with the
+// path to the part that failed, such as `.Items[2]: was 4`.
+type Matcher interface {
+	Match(v interface{}) bool
+	Describe() string
+	DescribeMismatch(v interface{}) string
+}
+
+// AssertThat checks that v matches m.
+func AssertThat(tb testing.TB, v interface{}, m Matcher, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	if !m.Match(v) {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertThat failed, %s\n\texpected: %s\n\t     but: %s", msg, m.Describe(), m.DescribeMismatch(v))
+		} else {
+			tb.Fatalf("AssertThat failed\n\texpected: %s\n\t     but: %s", m.Describe(), m.DescribeMismatch(v))
+		}
+	}
+}
+
+// MatcherFunc makes a Matcher from a predicate and its description. The
+// mismatch is described by the value.
+func MatcherFunc(description string, match func(v interface{}) bool) Matcher {
+	return &tFuncMatcher{description, match}
+}
+
+type tFuncMatcher struct {
+	description string
+	match       func(v interface{}) bool
+}
+
+func (m *tFuncMatcher) Match(v interface{}) bool              { return m.match(v) }
+func (m *tFuncMatcher) Describe() string                      { return m.description }
+func (m *tFuncMatcher) DescribeMismatch(v interface{}) string { return tWas(v) }
+
+// Eq matches values deeply equal to expected, with reflect.DeepEqual.
+func Eq(expected interface{}) Matcher {
+	return &tEqMatcher{expected}
+}
+
+type tEqMatcher struct {
+	expected interface{}
+}
+
+func (m *tEqMatcher) Match(v interface{}) bool {
+	return reflect.DeepEqual(m.expected, v)
+}
+
+func (m *tEqMatcher) Describe() string {
+	return "equal to " + tMatcherValue(m.expected)
+}
+
+func (m *tEqMatcher) DescribeMismatch(v interface{}) string {
+	if tFmtEqual(m.expected, v) {
+		return fmt.Sprintf("was %T(%v), not %T", v, v, m.expected)
+	}
+	return tWas(v)
+}
+
+// Not matches values that m does not match.
+func Not(m Matcher) Matcher {
+	return &tNotMatcher{m}
+}
+
+type tNotMatcher struct {
+	m Matcher
+}
+
+func (m *tNotMatcher) Match(v interface{}) bool              { return !m.m.Match(v) }
+func (m *tNotMatcher) Describe() string                      { return "not " + m.m.Describe() }
+func (m *tNotMatcher) DescribeMismatch(v interface{}) string { return tWas(v) }
+
+// AllOf matches values that every one of ms matches. The mismatch is the
+// one of the first failing matcher.
+func AllOf(ms ...Matcher) Matcher {
+	return &tAllOfMatcher{ms}
+}
+
+type tAllOfMatcher struct {
+	ms []Matcher
+}
+
+func (m *tAllOfMatcher) Match(v interface{}) bool {
+	for _, x := range m.ms {
+		if !x.Match(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *tAllOfMatcher) Describe() string {
+	return tJoinDescriptions(m.ms, " and ")
+}
+
+func (m *tAllOfMatcher) DescribeMismatch(v interface{}) string {
+	for _, x := range m.ms {
+		if !x.Match(v) {
+			return fmt.Sprintf("not %s, %s", x.Describe(), x.DescribeMismatch(v))
+		}
+	}
+	return tWas(v)
+}
+
+// AnyOf matches values that at least one of ms matches.
+func AnyOf(ms ...Matcher) Matcher {
+	return &tAnyOfMatcher{ms}
+}
+
+type tAnyOfMatcher struct {
+	ms []Matcher
+}
+
+func (m *tAnyOfMatcher) Match(v interface{}) bool {
+	for _, x := range m.ms {
+		if x.Match(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *tAnyOfMatcher) Describe() string {
+	return tJoinDescriptions(m.ms, " or ")
+}
+
+func (m *tAnyOfMatcher) DescribeMismatch(v interface{}) string {
+	return tWas(v)
+}
+
+// HasField matches structs, or pointers to structs, with an exported field
+// name whose value matches m. m is a Matcher, or a value for Eq.
+func HasField(name string, m interface{}) Matcher {
+	return &tFieldMatcher{name, tToMatcher(m)}
+}
+
+type tFieldMatcher struct {
+	name string
+	m    Matcher
+}
+
+// field returns the field of v, or why it has none.
+func (m *tFieldMatcher) field(v interface{}) (interface{}, string) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, tWas(v)
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Sprintf("was %T, not a struct", v)
+	}
+	f, ok := rv.Type().FieldByName(m.name)
+	if !ok || !f.IsExported() {
+		return nil, fmt.Sprintf("%T has no exported field %s", v, m.name)
+	}
+	for i, x := range f.Index {
+		if i > 0 && rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				return nil, fmt.Sprintf("field %s: embedded %v is nil", m.name, rv.Type())
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(x)
+	}
+	return rv.Interface(), ""
+}
+
+func (m *tFieldMatcher) Match(v interface{}) bool {
+	f, why := m.field(v)
+	return why == "" && m.m.Match(f)
+}
+
+func (m *tFieldMatcher) Describe() string {
+	return fmt.Sprintf("field %s %s", m.name, m.m.Describe())
+}
+
+func (m *tFieldMatcher) DescribeMismatch(v interface{}) string {
+	f, why := m.field(v)
+	if why != "" {
+		return why
+	}
+	return tMismatchPath("."+m.name, m.m.DescribeMismatch(f))
+}
+
+// HasKey matches maps with the key.
+func HasKey(key interface{}) Matcher {
+	return &tKeyMatcher{key: key}
+}
+
+// HasEntry matches maps with the key, whose value matches m. m is a
+// Matcher, or a value for Eq.
+func HasEntry(key, m interface{}) Matcher {
+	return &tKeyMatcher{key: key, m: tToMatcher(m)}
+}
+
+type tKeyMatcher struct {
+	key interface{}
+	m   Matcher // nil for HasKey
+}
+
+// value returns the entry of the key in v, or why it has none.
+func (m *tKeyMatcher) value(v interface{}) (interface{}, string) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Map {
+		return nil, fmt.Sprintf("was %T, not a map", v)
+	}
+	key := reflect.ValueOf(m.key)
+	if !key.IsValid() {
+		key = reflect.Zero(rv.Type().Key())
+	}
+	if !key.Type().AssignableTo(rv.Type().Key()) {
+		return nil, fmt.Sprintf("has key type %v, not %T", rv.Type().Key(), m.key)
+	}
+	val := rv.MapIndex(key)
+	if !val.IsValid() {
+		return nil, fmt.Sprintf("has no key %s in %s", tMatcherValue(m.key), tTruncatedView(v))
+	}
+	return val.Interface(), ""
+}
+
+func (m *tKeyMatcher) Match(v interface{}) bool {
+	val, why := m.value(v)
+	return why == "" && (m.m == nil || m.m.Match(val))
+}
+
+func (m *tKeyMatcher) Describe() string {
+	if m.m == nil {
+		return "map with key " + tMatcherValue(m.key)
+	}
+	return fmt.Sprintf("map with key %s %s", tMatcherValue(m.key), m.m.Describe())
+}
+
+func (m *tKeyMatcher) DescribeMismatch(v interface{}) string {
+	val, why := m.value(v)
+	if why != "" || m.m == nil {
+		return why
+	}
+	return tMismatchPath(fmt.Sprintf("[%s]", tMatcherValue(m.key)), m.m.DescribeMismatch(val))
+}
+
+// Each matches slices, arrays and maps whose elements all match m. m is a
+// Matcher, or a value for Eq. The mismatch is the one of the first element
+// that fails, in key order for maps.
+func Each(m interface{}) Matcher {
+	return &tEachMatcher{tToMatcher(m)}
+}
+
+type tEachMatcher struct {
+	m Matcher
+}
+
+func (m *tEachMatcher) Match(v interface{}) bool {
+	path, _, why := m.firstMismatch(v)
+	return path == "" && why == ""
+}
+
+func (m *tEachMatcher) Describe() string {
+	return "every element " + m.m.Describe()
+}
+
+func (m *tEachMatcher) DescribeMismatch(v interface{}) string {
+	path, elem, why := m.firstMismatch(v)
+	if why != "" {
+		return why
+	}
+	return tMismatchPath(path, m.m.DescribeMismatch(elem))
+}
+
+// firstMismatch returns the path and value of the first element that does
+// not match, or why v has no elements. Both path and why are empty if every
+// element matches.
+func (m *tEachMatcher) firstMismatch(v interface{}) (path string, elem interface{}, why string) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if elem := rv.Index(i).Interface(); !m.m.Match(elem) {
+				return fmt.Sprintf("[%d]", i), elem, ""
+			}
+		}
+		return "", nil, ""
+	case reflect.Map:
+		keys := rv.MapKeys()
+		tSortValues(keys)
+		for _, key := range keys {
+			if elem := rv.MapIndex(key).Interface(); !m.m.Match(elem) {
+				return fmt.Sprintf("[%s]", tMatcherValue(key.Interface())), elem, ""
+			}
+		}
+		return "", nil, ""
+	}
+	return "", nil, fmt.Sprintf("was %T, without elements", v)
+}
+
+// ContainsElement matches slices and arrays with an element that matches
+// m. m is a Matcher, or a value for Eq.
+func ContainsElement(m interface{}) Matcher {
+	return &tContainsMatcher{tToMatcher(m)}
+}
+
+type tContainsMatcher struct {
+	m Matcher
+}
+
+func (m *tContainsMatcher) Match(v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	if !tIsList(rv) {
+		return false
+	}
+	for i := 0; i < rv.Len(); i++ {
+		if m.m.Match(rv.Index(i).Interface()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *tContainsMatcher) Describe() string {
+	return "containing an element " + m.m.Describe()
+}
+
+func (m *tContainsMatcher) DescribeMismatch(v interface{}) string {
+	if !tIsList(reflect.ValueOf(v)) {
+		return fmt.Sprintf("was %T, not a slice or array", v)
+	}
+	return tWas(v)
+}
+
+// MatchesRegexp matches strings, []byte and fmt.Stringer values with a
+// match of the regexp pattern. It panics if pattern is invalid.
+func MatchesRegexp(pattern string) Matcher {
+	re, err := tCompileRegexp(pattern)
+	if err != nil {
+		panic(fmt.Sprintf("assert: MatchesRegexp called with invalid pattern, err = %v", err))
+	}
+	return MatcherFunc(fmt.Sprintf("matching %q", pattern), func(v interface{}) bool {
+		s, ok := tStringOf(v)
+		return ok && re.MatchString(s)
+	})
+}
+
+// Approx matches numbers within abs of expected.
+func Approx(expected, abs float64) Matcher {
+	return &tApproxMatcher{expected, abs}
+}
+
+type tApproxMatcher struct {
+	expected, abs float64
+}
+
+func (m *tApproxMatcher) Match(v interface{}) bool {
+	f, ok := tFloatOf(v)
+	return ok && math.Abs(f-m.expected) <= m.abs
+}
+
+func (m *tApproxMatcher) Describe() string {
+	return fmt.Sprintf("within %v of %v", m.abs, m.expected)
+}
+
+func (m *tApproxMatcher) DescribeMismatch(v interface{}) string {
+	f, ok := tFloatOf(v)
+	if !ok {
+		return fmt.Sprintf("was %T, not a number", v)
+	}
+	return fmt.Sprintf("was %v, off by %v", v, math.Abs(f-m.expected))
+}
+
+// tFloatOf converts a value of integer or float kind to float64.
+func tFloatOf(v interface{}) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+// tToMatcher returns m if it is a Matcher, or Eq(m).
+func tToMatcher(m interface{}) Matcher {
+	if x, ok := m.(Matcher); ok {
+		return x
+	}
+	return Eq(m)
+}
+
+// tMismatchPath prefixes the mismatch of an inner matcher with path,
+// joining nested paths like ".Items[2].Name".
+func tMismatchPath(path, mismatch string) string {
+	if strings.HasPrefix(mismatch, ".") || strings.HasPrefix(mismatch, "[") {
+		return path + mismatch
+	}
+	return path + ": " + mismatch
+}
+
+func tJoinDescriptions(ms []Matcher, sep string) string {
+	descriptions := make([]string, len(ms))
+	for i, m := range ms {
+		descriptions[i] = m.Describe()
+	}
+	return "(" + strings.Join(descriptions, sep) + ")"
+}
+
+func tWas(v interface{}) string {
+	return "was " + tMatcherValue(v)
+}
+
+// tMatcherValue formats v with %q for strings and %v otherwise.
+func tMatcherValue(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprintf("%v", v)
+}
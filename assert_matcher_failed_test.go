@@ -0,0 +1,23 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// go test -assert.failed
+
+package assert
+
+import (
+	"testing"
+)
+
+func TestAssertThat_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	type item struct {
+		Name  string
+		Price float64
+	}
+	items := []item{{"apple", 0.5}, {"pear", 0.75}}
+	AssertThat(t, items, Each(HasField("Price", Approx(0.5, 0.1))), "prices")
+}
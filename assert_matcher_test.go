@@ -0,0 +1,84 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert_test
+
+import (
+	"testing"
+
+	. "github.com/chai2010/assert"
+)
+
+type tOrder struct {
+	ID    int
+	Tags  map[string]string
+	Items []tItem
+}
+
+type tItem struct {
+	Name  string
+	Price float64
+}
+
+type tOuter struct {
+	*tInner
+}
+
+type tInner struct {
+	X int
+}
+
+var tTestOrder = &tOrder{
+	ID:   7,
+	Tags: map[string]string{"region": "eu"},
+	Items: []tItem{
+		{Name: "apple", Price: 0.5},
+		{Name: "pear", Price: 0.75},
+	},
+}
+
+func TestAssertThat(t *testing.T) {
+	AssertThat(t, 3, Eq(3))
+	AssertThat(t, 3, Not(Eq(4)))
+	AssertThat(t, "hello", AllOf(MatchesRegexp(`^h`), Not(Eq(""))))
+	AssertThat(t, 2, AnyOf(Eq(1), Eq(2)))
+	AssertThat(t, map[string]int{"a": 1}, HasKey("a"))
+	AssertThat(t, []int{1, 2, 3}, ContainsElement(2))
+	AssertThat(t, []float64{0.99, 1.01}, Each(Approx(1, 0.02)))
+	AssertThat(t, tTestOrder, AllOf(
+		HasField("ID", 7),
+		HasField("Tags", HasEntry("region", "eu")),
+		HasField("Items", Each(HasField("Price", Approx(0.6, 0.2)))),
+		HasField("Items", ContainsElement(HasField("Name", "pear"))),
+	))
+
+	even := MatcherFunc("even", func(v interface{}) bool {
+		n, ok := v.(int)
+		return ok && n%2 == 0
+	})
+	AssertThat(t, []int{2, 4}, Each(even))
+	AssertThat(t, tOuter{&tInner{X: 1}}, HasField("X", 1))
+	AssertThat(t, tOuter{}, Not(HasField("X", 1)))
+}
+
+func TestAssertThat_mismatch(t *testing.T) {
+	for _, tt := range []struct {
+		v        interface{}
+		m        Matcher
+		mismatch string
+	}{
+		{3, Eq(4), "was 3"},
+		{int64(3), Eq(3), "was int64(3), not int"},
+		{tTestOrder, HasField("Items", Each(HasField("Price", Approx(0.5, 0.1)))), ".Items[1].Price: was 0.75, off by 0.25"},
+		{tTestOrder, HasField("Tags", HasEntry("region", "us")), `.Tags["region"]: was "eu"`},
+		{tTestOrder, HasField("Tags", HasKey("zone")), `.Tags: has no key "zone" in map[region:eu]`},
+		{tTestOrder, HasField("Missing", 1), "*assert_test.tOrder has no exported field Missing"},
+		{tOuter{}, HasField("X", 1), "field X: embedded *assert_test.tInner is nil"},
+		{3, AllOf(Not(Eq(1)), Eq(2)), "not equal to 2, was 3"},
+		{map[string]int{"a": 1, "b": 2}, Each(Eq(1)), `["b"]: was 2`},
+	} {
+		AssertFalse(t, tt.m.Match(tt.v), tt.m.Describe())
+		AssertEqual(t, tt.mismatch, tt.m.DescribeMismatch(tt.v))
+	}
+}
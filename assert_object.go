@@ -0,0 +1,556 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"image"
+	"image/color"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// Assertions binds the Assert* functions to a testing.TB. It has a method
+// for every non-generic Assert* function, named without the Assert prefix
+// (Assert and Assertf keep their names), taking the same arguments
+// without tb. Generic assertions, and other helpers, can be run with Do.
+//
+// Example:
+//
+//	func TestParse(t *testing.T) {
+//		a := assert.New(t)
+//		v, err := Parse("1 + 2")
+//		a.Nil(err)
+//		a.Equal(3, v.Eval())
+//		a.Do(func(tb testing.TB) {
+//			assert.AssertLess(tb, v.Depth(), 10)
+//		})
+//	}
+type Assertions struct {
+	tb testing.TB
+}
+
+// New returns the require flavor of Assertions for tb, like Require.
+func New(tb testing.TB) *Assertions {
+	return Require(tb)
+}
+
+// Require returns Assertions that stop the test at the first failure with
+// tb.Fatalf, like the Assert* functions.
+func Require(tb testing.TB) *Assertions {
+	return &Assertions{tb: tb}
+}
+
+// Expect returns Assertions that report failures with tb.Errorf, and let
+// the test go on.
+func Expect(tb testing.TB) *Assertions {
+	return &Assertions{tb: &tExpectTB{TB: tb}}
+}
+
+// Do runs f with the testing.TB of as. With the expect flavor, a failure
+// stops f, but not the test.
+func (as *Assertions) Do(f func(tb testing.TB)) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	f(as.tb)
+}
+
+// tExpectTB turns the fatal failures of the assertions given it into
+// errors.
+type tExpectTB struct {
+	testing.TB
+}
+
+func (x *tExpectTB) FailNow() {
+	x.TB.Fail()
+	panic(tStop{})
+}
+
+func (x *tExpectTB) Fatal(args ...interface{}) {
+	x.TB.Helper()
+	x.TB.Error(args...)
+	panic(tStop{})
+}
+
+func (x *tExpectTB) Fatalf(format string, args ...interface{}) {
+	x.TB.Helper()
+	x.TB.Errorf(format, args...)
+	panic(tStop{})
+}
+
+func (as *Assertions) Assert(condition bool, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	Assert(as.tb, condition, args...)
+}
+
+func (as *Assertions) Assertf(condition bool, format string, a ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	Assertf(as.tb, condition, format, a...)
+}
+
+func (as *Assertions) Nil(p interface{}, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertNil(as.tb, p, args...)
+}
+
+func (as *Assertions) NotNil(p interface{}, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertNotNil(as.tb, p, args...)
+}
+
+func (as *Assertions) True(condition bool, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertTrue(as.tb, condition, args...)
+}
+
+func (as *Assertions) False(condition bool, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertFalse(as.tb, condition, args...)
+}
+
+func (as *Assertions) Equal(expected, got interface{}, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertEqual(as.tb, expected, got, args...)
+}
+
+func (as *Assertions) NotEqual(expected, got interface{}, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertNotEqual(as.tb, expected, got, args...)
+}
+
+func (as *Assertions) Near(expected, got, abs float64, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertNear(as.tb, expected, got, abs, args...)
+}
+
+func (as *Assertions) Between(min, max, val float64, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertBetween(as.tb, min, max, val, args...)
+}
+
+func (as *Assertions) NotBetween(min, max, val float64, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertNotBetween(as.tb, min, max, val, args...)
+}
+
+func (as *Assertions) Match(expectedPattern string, got []byte, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertMatch(as.tb, expectedPattern, got, args...)
+}
+
+func (as *Assertions) MatchString(expectedPattern, got string, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertMatchString(as.tb, expectedPattern, got, args...)
+}
+
+func (as *Assertions) SliceContain(slice, val interface{}, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertSliceContain(as.tb, slice, val, args...)
+}
+
+func (as *Assertions) SliceNotContain(slice, val interface{}, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertSliceNotContain(as.tb, slice, val, args...)
+}
+
+func (as *Assertions) MapEqual(expected, got interface{}, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertMapEqual(as.tb, expected, got, args...)
+}
+
+func (as *Assertions) MapContain(m, key, val interface{}, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertMapContain(as.tb, m, key, val, args...)
+}
+
+func (as *Assertions) MapContainKey(m, key interface{}, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertMapContainKey(as.tb, m, key, args...)
+}
+
+func (as *Assertions) MapContainVal(m, val interface{}, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertMapContainVal(as.tb, m, val, args...)
+}
+
+func (as *Assertions) MapNotContain(m, key, val interface{}, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertMapNotContain(as.tb, m, key, val, args...)
+}
+
+func (as *Assertions) MapNotContainKey(m, key interface{}, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertMapNotContainKey(as.tb, m, key, args...)
+}
+
+func (as *Assertions) MapNotContainVal(m, val interface{}, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertMapNotContainVal(as.tb, m, val, args...)
+}
+
+func (as *Assertions) Zero(val interface{}, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertZero(as.tb, val, args...)
+}
+
+func (as *Assertions) NotZero(val interface{}, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertNotZero(as.tb, val, args...)
+}
+
+func (as *Assertions) FileExists(path string, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertFileExists(as.tb, path, args...)
+}
+
+func (as *Assertions) FileNotExists(path string, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertFileNotExists(as.tb, path, args...)
+}
+
+func (as *Assertions) Implements(interfaceObj, obj interface{}, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertImplements(as.tb, interfaceObj, obj, args...)
+}
+
+func (as *Assertions) SameType(expectedType interface{}, obj interface{}, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertSameType(as.tb, expectedType, obj, args...)
+}
+
+func (as *Assertions) Panic(f func(), args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertPanic(as.tb, f, args...)
+}
+
+func (as *Assertions) NotPanic(f func(), args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertNotPanic(as.tb, f, args...)
+}
+
+func (as *Assertions) ImageEqual(expected, got image.Image, maxDelta color.Color, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertImageEqual(as.tb, expected, got, maxDelta, args...)
+}
+
+func (as *Assertions) Allocs(maxAllocs float64, f func(), args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertAllocs(as.tb, maxAllocs, f, args...)
+}
+
+func (as *Assertions) NoAllocs(f func(), args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertNoAllocs(as.tb, f, args...)
+}
+
+func (as *Assertions) FasterThan(baselineName string, f func(b *testing.B), tolerance float64, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertFasterThan(as.tb, baselineName, f, tolerance, args...)
+}
+
+func (as *Assertions) BigEqual(expected, got interface{}, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertBigEqual(as.tb, expected, got, args...)
+}
+
+func (as *Assertions) BigNear(expected, got *big.Float, rel float64, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertBigNear(as.tb, expected, got, rel, args...)
+}
+
+func (as *Assertions) CSVEqual(expected, got []byte, opts *CSVOptions, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertCSVEqual(as.tb, expected, got, opts, args...)
+}
+
+func (as *Assertions) CompletesWithin(timeout time.Duration, f func(), args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertCompletesWithin(as.tb, timeout, f, args...)
+}
+
+func (as *Assertions) NearRel(expected, got, rel float64, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertNearRel(as.tb, expected, got, rel, args...)
+}
+
+func (as *Assertions) NearULP(expected, got float64, ulps uint64, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertNearULP(as.tb, expected, got, ulps, args...)
+}
+
+func (as *Assertions) IsClose(expected, got, rel, abs float64, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertIsClose(as.tb, expected, got, rel, abs, args...)
+}
+
+func (as *Assertions) NaN(val float64, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertNaN(as.tb, val, args...)
+}
+
+func (as *Assertions) NotNaN(val float64, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertNotNaN(as.tb, val, args...)
+}
+
+func (as *Assertions) Inf(val float64, sign int, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertInf(as.tb, val, sign, args...)
+}
+
+func (as *Assertions) Finite(val float64, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertFinite(as.tb, val, args...)
+}
+
+func (as *Assertions) HTTPStatus(target interface{}, req *http.Request, code int, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertHTTPStatus(as.tb, target, req, code, args...)
+}
+
+func (as *Assertions) HTTPHeader(target interface{}, req *http.Request, key, value string, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertHTTPHeader(as.tb, target, req, key, value, args...)
+}
+
+func (as *Assertions) HTTPBodyContains(target interface{}, req *http.Request, substr string, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertHTTPBodyContains(as.tb, target, req, substr, args...)
+}
+
+func (as *Assertions) HTTPBodyJSON(target interface{}, req *http.Request, expected interface{}, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertHTTPBodyJSON(as.tb, target, req, expected, args...)
+}
+
+func (as *Assertions) HTTPRedirect(target interface{}, req *http.Request, location string, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertHTTPRedirect(as.tb, target, req, location, args...)
+}
+
+func (as *Assertions) Len(obj interface{}, n int, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertLen(as.tb, obj, n, args...)
+}
+
+func (as *Assertions) Empty(obj interface{}, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertEmpty(as.tb, obj, args...)
+}
+
+func (as *Assertions) NotEmpty(obj interface{}, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertNotEmpty(as.tb, obj, args...)
+}
+
+func (as *Assertions) Cap(obj interface{}, n int, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertCap(as.tb, obj, n, args...)
+}
+
+func (as *Assertions) NotMatch(pattern string, got interface{}, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertNotMatch(as.tb, pattern, got, args...)
+}
+
+func (as *Assertions) MatchGroups(pattern string, got interface{}, expectedGroups map[string]string, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertMatchGroups(as.tb, pattern, got, expectedGroups, args...)
+}
+
+func (as *Assertions) EveryLineMatches(pattern string, got interface{}, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertEveryLineMatches(as.tb, pattern, got, args...)
+}
+
+func (as *Assertions) ElementsMatch(expected, got interface{}, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertElementsMatch(as.tb, expected, got, args...)
+}
+
+func (as *Assertions) Subset(super, sub interface{}, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertSubset(as.tb, super, sub, args...)
+}
+
+func (as *Assertions) Superset(sub, super interface{}, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertSuperset(as.tb, sub, super, args...)
+}
+
+func (as *Assertions) Disjoint(a, b interface{}, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertDisjoint(as.tb, a, b, args...)
+}
+
+func (as *Assertions) Intersects(a, b interface{}, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertIntersects(as.tb, a, b, args...)
+}
+
+func (as *Assertions) StringEqual(expected, got string, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertStringEqual(as.tb, expected, got, args...)
+}
+
+func (as *Assertions) Contains(s interface{}, substr string, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertContains(as.tb, s, substr, args...)
+}
+
+func (as *Assertions) NotContains(s interface{}, substr string, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertNotContains(as.tb, s, substr, args...)
+}
+
+func (as *Assertions) HasPrefix(s interface{}, prefix string, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertHasPrefix(as.tb, s, prefix, args...)
+}
+
+func (as *Assertions) HasSuffix(s interface{}, suffix string, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertHasSuffix(as.tb, s, suffix, args...)
+}
+
+func (as *Assertions) EqualFold(expected, got interface{}, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertEqualFold(as.tb, expected, got, args...)
+}
+
+func (as *Assertions) Count(s interface{}, substr string, n int, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertCount(as.tb, s, substr, n, args...)
+}
+
+func (as *Assertions) Lines(got interface{}, expectedLines []string, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertLines(as.tb, got, expectedLines, args...)
+}
+
+func (as *Assertions) StructuredEqual(expected, got []byte, format StructuredFormat, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertStructuredEqual(as.tb, expected, got, format, args...)
+}
+
+func (as *Assertions) TimeEqual(expected, got time.Time, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertTimeEqual(as.tb, expected, got, args...)
+}
+
+func (as *Assertions) WithinDuration(expected, got time.Time, delta time.Duration, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertWithinDuration(as.tb, expected, got, delta, args...)
+}
+
+func (as *Assertions) Before(a, b time.Time, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertBefore(as.tb, a, b, args...)
+}
+
+func (as *Assertions) After(a, b time.Time, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertAfter(as.tb, a, b, args...)
+}
+
+func (as *Assertions) DurationNear(expected, got, abs time.Duration, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertDurationNear(as.tb, expected, got, abs, args...)
+}
+
+func (as *Assertions) SameDay(a, b time.Time, loc *time.Location, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertSameDay(as.tb, a, b, loc, args...)
+}
+
+func (as *Assertions) That(v interface{}, m Matcher, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertThat(as.tb, v, m, args...)
+}
+
+func (as *Assertions) FieldsMatch(expectedPartial, got interface{}, args ...interface{}) {
+	as.tb.Helper()
+	defer tRecoverStop()
+	AssertFieldsMatch(as.tb, expectedPartial, got, args...)
+}
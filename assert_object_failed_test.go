@@ -0,0 +1,32 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// go test -assert.failed
+
+package assert
+
+import (
+	"testing"
+)
+
+func TestRequire_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	a := Require(t)
+	a.Equal(1, 2)
+	a.Equal(3, 4) // not reached
+}
+
+func TestExpect_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	a := Expect(t)
+	a.Equal(1, 2)
+	a.NotNil(nil, "second failure")
+	a.Do(func(tb testing.TB) {
+		AssertGreater(tb, 1, 2)
+	})
+}
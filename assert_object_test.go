@@ -0,0 +1,41 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert_test
+
+import (
+	"testing"
+
+	. "github.com/chai2010/assert"
+)
+
+func TestNew(t *testing.T) {
+	a := New(t)
+	a.Assert(true)
+	a.Nil(nil)
+	a.Equal(1, 1)
+	a.MapContainKey(map[string]int{"a": 1}, "a")
+	a.Contains("hello", "ell")
+	a.That(42, Not(Eq(0)))
+	a.Do(func(tb testing.TB) {
+		AssertGreater(tb, 2, 1)
+	})
+}
+
+func TestExpect(t *testing.T) {
+	tb := &tCaptureTB{TB: t}
+	a := Expect(tb)
+	a.Equal(1, 2)
+	a.Len([]int{1}, 1)
+	a.HTTPStatus(nil, nil, 200)
+	a.Do(func(tb testing.TB) {
+		AssertLess(tb, 2, 1)
+		panic("not reached")
+	})
+	AssertEqual(t, []string{
+		"AssertEqual failed, expected = 1, got = 2",
+		"AssertHTTPStatus called with non-handler value of type <nil>",
+		"AssertLess failed, expected 2 < 1",
+	}, tb.errors)
+}
@@ -0,0 +1,45 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+// Option changes how an assertion compares values. Options are passed
+// among the message arguments of the assertions that document them, and
+// are left out of the failure message.
+type Option int
+
+const (
+	// NaNEqual makes NaN equal to NaN.
+	NaNEqual Option = iota + 1
+
+	// HeapProfile writes the allocations of a failed allocation assertion,
+	// by stack, to a temporary file.
+	HeapProfile
+
+	// FmtEqual compares elements by their %v formatting, like AssertEqual.
+	FmtEqual
+)
+
+type tOptions struct {
+	NaNEqual    bool
+	HeapProfile bool
+	FmtEqual    bool
+}
+
+// tSplitOptions separates options from message arguments.
+func tSplitOptions(args []interface{}) (opts tOptions, rest []interface{}) {
+	for _, arg := range args {
+		switch arg {
+		case NaNEqual:
+			opts.NaNEqual = true
+		case HeapProfile:
+			opts.HeapProfile = true
+		case FmtEqual:
+			opts.FmtEqual = true
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	return opts, rest
+}
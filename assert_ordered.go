@@ -0,0 +1,124 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"cmp"
+	"fmt"
+	"testing"
+)
+
+// Bounds selects which ends of a range AssertInRange includes.
+type Bounds int
+
+const (
+	BoundsClosed     Bounds = iota // [min, max]
+	BoundsOpen                     // (min, max)
+	BoundsClosedOpen               // [min, max)
+	BoundsOpenClosed               // (min, max]
+)
+
+func AssertGreater[T cmp.Ordered](tb testing.TB, a, b T, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	if !(a > b) {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertGreater failed, expected %s > %s, %s", tOrderedString(a), tOrderedString(b), msg)
+		} else {
+			tb.Fatalf("AssertGreater failed, expected %s > %s", tOrderedString(a), tOrderedString(b))
+		}
+	}
+}
+
+func AssertGreaterOrEqual[T cmp.Ordered](tb testing.TB, a, b T, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	if !(a >= b) {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertGreaterOrEqual failed, expected %s >= %s, %s", tOrderedString(a), tOrderedString(b), msg)
+		} else {
+			tb.Fatalf("AssertGreaterOrEqual failed, expected %s >= %s", tOrderedString(a), tOrderedString(b))
+		}
+	}
+}
+
+func AssertLess[T cmp.Ordered](tb testing.TB, a, b T, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	if !(a < b) {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertLess failed, expected %s < %s, %s", tOrderedString(a), tOrderedString(b), msg)
+		} else {
+			tb.Fatalf("AssertLess failed, expected %s < %s", tOrderedString(a), tOrderedString(b))
+		}
+	}
+}
+
+func AssertLessOrEqual[T cmp.Ordered](tb testing.TB, a, b T, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	if !(a <= b) {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertLessOrEqual failed, expected %s <= %s, %s", tOrderedString(a), tOrderedString(b), msg)
+		} else {
+			tb.Fatalf("AssertLessOrEqual failed, expected %s <= %s", tOrderedString(a), tOrderedString(b))
+		}
+	}
+}
+
+// AssertInRange checks min <= val <= max, or the strict comparisons
+// selected by bounds. Values are compared in their own type, so int64 and
+// uint64 values keep full precision, unlike AssertBetween.
+func AssertInRange[T cmp.Ordered](tb testing.TB, min, max, val T, bounds Bounds, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	var lowOK, highOK bool
+	switch bounds {
+	case BoundsClosed:
+		lowOK, highOK = min <= val, val <= max
+	case BoundsOpen:
+		lowOK, highOK = min < val, val < max
+	case BoundsClosedOpen:
+		lowOK, highOK = min <= val, val < max
+	case BoundsOpenClosed:
+		lowOK, highOK = min < val, val <= max
+	default:
+		tb.Fatalf("AssertInRange called with invalid bounds %d", int(bounds))
+	}
+	if !lowOK || !highOK {
+		r := bounds.format(tOrderedString(min), tOrderedString(max))
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertInRange failed, range = %s, val = %s, %s", r, tOrderedString(val), msg)
+		} else {
+			tb.Fatalf("AssertInRange failed, range = %s, val = %s", r, tOrderedString(val))
+		}
+	}
+}
+
+func (b Bounds) format(min, max string) string {
+	switch b {
+	case BoundsOpen:
+		return "(" + min + ", " + max + ")"
+	case BoundsClosedOpen:
+		return "[" + min + ", " + max + ")"
+	case BoundsOpenClosed:
+		return "(" + min + ", " + max + "]"
+	default:
+		return "[" + min + ", " + max + "]"
+	}
+}
+
+// tOrderedString quotes strings, so that bounds such as "" stay visible.
+func tOrderedString[T cmp.Ordered](v T) string {
+	if s, ok := any(v).(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprintf("%v", v)
+}
@@ -0,0 +1,54 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// go test -assert.failed
+
+package assert
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAssertGreater_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertGreater(t, uint64(math.MaxUint64-1), math.MaxUint64)
+}
+
+func TestAssertGreaterOrEqual_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertGreaterOrEqual(t, math.NaN(), math.NaN())
+}
+
+func TestAssertLess_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertLess(t, "b", "")
+}
+
+func TestAssertLessOrEqual_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertLessOrEqual(t, int64(1<<53+1), 1<<53)
+}
+
+func TestAssertInRange_failed_01(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertInRange(t, 0, 255, 255, BoundsClosedOpen)
+}
+
+func TestAssertInRange_failed_02(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertInRange(t, int64(0), 1<<62, 0, BoundsOpenClosed)
+}
@@ -0,0 +1,43 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	. "github.com/chai2010/assert"
+)
+
+func TestAssertGreater(t *testing.T) {
+	AssertGreater(t, 2, 1)
+	AssertGreater(t, uint64(math.MaxUint64), math.MaxUint64-1)
+	AssertGreater(t, "b", "a")
+	AssertGreater(t, time.Second, time.Millisecond)
+}
+
+func TestAssertGreaterOrEqual(t *testing.T) {
+	AssertGreaterOrEqual(t, 1.5, 1.5)
+	AssertGreaterOrEqual(t, int64(math.MaxInt64), math.MaxInt64-1)
+}
+
+func TestAssertLess(t *testing.T) {
+	AssertLess(t, int64(1<<53), 1<<53+1)
+	AssertLess(t, "abc", "abd")
+}
+
+func TestAssertLessOrEqual(t *testing.T) {
+	AssertLessOrEqual(t, uint8(255), 255)
+}
+
+func TestAssertInRange(t *testing.T) {
+	AssertInRange(t, 0, 255, 0, BoundsClosed)
+	AssertInRange(t, 0, 255, 255, BoundsClosed)
+	AssertInRange(t, 0, 255, 254, BoundsClosedOpen)
+	AssertInRange(t, 0, 255, 1, BoundsOpenClosed)
+	AssertInRange(t, uint64(1<<63), math.MaxUint64, math.MaxUint64-1, BoundsOpen)
+	AssertInRange(t, "a", "c", "b", BoundsOpen)
+}
@@ -0,0 +1,148 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// tRegexpCache holds every pattern compiled by the AssertMatch* functions.
+// Patterns in tests are nearly always constants, so it is never pruned.
+var tRegexpCache struct {
+	sync.RWMutex
+	m map[string]*regexp.Regexp
+}
+
+func tCompileRegexp(pattern string) (*regexp.Regexp, error) {
+	tRegexpCache.RLock()
+	re, ok := tRegexpCache.m[pattern]
+	tRegexpCache.RUnlock()
+	if ok {
+		return re, nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	tRegexpCache.Lock()
+	if tRegexpCache.m == nil {
+		tRegexpCache.m = make(map[string]*regexp.Regexp)
+	}
+	tRegexpCache.m[pattern] = re
+	tRegexpCache.Unlock()
+	return re, nil
+}
+
+func AssertNotMatch(tb testing.TB, pattern string, got interface{}, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	str, ok := tStringOf(got)
+	if !ok {
+		tb.Fatalf("AssertNotMatch called with non-string value of type %T", got)
+	}
+	re, err := tCompileRegexp(pattern)
+	if err != nil {
+		tb.Fatalf("AssertNotMatch called with invalid pattern %q, err = %v", pattern, err)
+	}
+	if loc := re.FindStringIndex(str); loc != nil {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertNotMatch failed, pattern = %q, got = %q, %s\n%s", pattern, str, msg, tHighlight(str, loc[0], loc[1]-loc[0], -1, "match"))
+		} else {
+			tb.Fatalf("AssertNotMatch failed, pattern = %q, got = %q\n%s", pattern, str, tHighlight(str, loc[0], loc[1]-loc[0], -1, "match"))
+		}
+	}
+}
+
+// AssertMatchGroups matches pattern against got and compares the named
+// capture groups of the first match with expectedGroups. A group that did
+// not take part in the match equals "".
+func AssertMatchGroups(tb testing.TB, pattern string, got interface{}, expectedGroups map[string]string, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	str, ok := tStringOf(got)
+	if !ok {
+		tb.Fatalf("AssertMatchGroups called with non-string value of type %T", got)
+	}
+	re, err := tCompileRegexp(pattern)
+	if err != nil {
+		tb.Fatalf("AssertMatchGroups called with invalid pattern %q, err = %v", pattern, err)
+	}
+	names := make([]string, 0, len(expectedGroups))
+	for name := range expectedGroups {
+		if re.SubexpIndex(name) < 0 {
+			tb.Fatalf("AssertMatchGroups called with unknown group %q for pattern %q", name, pattern)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	match := re.FindStringSubmatchIndex(str)
+	if match == nil {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertMatchGroups failed, pattern = %q, got = %q, no match, %s", pattern, str, msg)
+		} else {
+			tb.Fatalf("AssertMatchGroups failed, pattern = %q, got = %q, no match", pattern, str)
+		}
+		return
+	}
+	var diffs []string
+	for _, name := range names {
+		i, expected := re.SubexpIndex(name), expectedGroups[name]
+		switch {
+		case match[2*i] < 0:
+			if expected != "" {
+				diffs = append(diffs, fmt.Sprintf("%s: expected = %q, got = <unmatched>", name, expected))
+			}
+		case str[match[2*i]:match[2*i+1]] != expected:
+			diffs = append(diffs, fmt.Sprintf("%s: expected = %q, got = %q", name, expected, str[match[2*i]:match[2*i+1]]))
+		}
+	}
+	if len(diffs) != 0 {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertMatchGroups failed, pattern = %q, got = %q, %s\n\t%s", pattern, str, msg, strings.Join(diffs, "\n\t"))
+		} else {
+			tb.Fatalf("AssertMatchGroups failed, pattern = %q, got = %q\n\t%s", pattern, str, strings.Join(diffs, "\n\t"))
+		}
+	}
+}
+
+// AssertEveryLineMatches checks each line of got against pattern and lists
+// every line that does not match. A final newline does not start a line.
+func AssertEveryLineMatches(tb testing.TB, pattern string, got interface{}, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	str, ok := tStringOf(got)
+	if !ok {
+		tb.Fatalf("AssertEveryLineMatches called with non-string value of type %T", got)
+	}
+	re, err := tCompileRegexp(pattern)
+	if err != nil {
+		tb.Fatalf("AssertEveryLineMatches called with invalid pattern %q, err = %v", pattern, err)
+	}
+	lines, _ := tSplitLines(str)
+	var failed []string
+	for i, line := range lines {
+		if !re.MatchString(line) {
+			failed = append(failed, fmt.Sprintf("line %d: %q", i+1, line))
+		}
+	}
+	if len(failed) != 0 {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertEveryLineMatches failed, pattern = %q, %d of %d lines, %s\n\t%s", pattern, len(failed), len(lines), msg, strings.Join(failed, "\n\t"))
+		} else {
+			tb.Fatalf("AssertEveryLineMatches failed, pattern = %q, %d of %d lines\n\t%s", pattern, len(failed), len(lines), strings.Join(failed, "\n\t"))
+		}
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// go test -assert.failed
+
+package assert
+
+import (
+	"testing"
+)
+
+func TestAssertNotMatch_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertNotMatch(t, `\d+`, "order 1234 shipped")
+}
+
+func TestAssertMatchGroups_failed_01(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertMatchGroups(t, `(?P<user>\w+)@(?P<host>[\w.]+)`, "chaishushan@gmail.com",
+		map[string]string{"user": "chai2010", "host": "gmail.com"},
+	)
+}
+
+func TestAssertMatchGroups_failed_02(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertMatchGroups(t, `(?P<user>\w+)@(?P<host>[\w.]+)`, "no mail here",
+		map[string]string{"user": "chai2010"},
+	)
+}
+
+func TestAssertEveryLineMatches_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertEveryLineMatches(t, `^\w+=\d+$`, "a=1\nb = 2\nc=3\nd=x\n")
+}
@@ -0,0 +1,35 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert_test
+
+import (
+	"testing"
+
+	. "github.com/chai2010/assert"
+)
+
+func TestAssertNotMatch(t *testing.T) {
+	AssertNotMatch(t, `\.go$`, "assert.cc")
+	AssertNotMatch(t, `^\d+$`, []byte("12a"))
+}
+
+func TestAssertMatchGroups(t *testing.T) {
+	AssertMatchGroups(t, `(?P<user>\w+)@(?P<host>[\w.]+)`, "mail chaishushan@gmail.com now",
+		map[string]string{"user": "chaishushan", "host": "gmail.com"},
+	)
+	AssertMatchGroups(t, `v(?P<major>\d+)(\.(?P<minor>\d+))?`, []byte("v2"),
+		map[string]string{"major": "2", "minor": ""},
+	)
+}
+
+func TestAssertEveryLineMatches(t *testing.T) {
+	AssertEveryLineMatches(t, `^\w+=\d+$`, "a=1\nb=2\nc=3\n")
+}
+
+func BenchmarkAssertMatchString(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		AssertMatchString(b, `^\w+@\w+\.com$`, "chaishushan@gmail.com")
+	}
+}
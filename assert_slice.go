@@ -0,0 +1,279 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// AssertElementsMatch checks that two slices or arrays hold the same
+// elements with the same counts, in any order. Elements are compared with
+// reflect.DeepEqual, like AssertSliceContain, or with the FmtEqual option
+// by their %v formatting, like AssertEqual, so that 1 matches int64(1).
+func AssertElementsMatch(tb testing.TB, expected, got interface{}, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	opts, args := tSplitOptions(args)
+	expectedVal, gotVal := reflect.ValueOf(expected), reflect.ValueOf(got)
+	if !tIsList(expectedVal) {
+		tb.Fatalf("AssertElementsMatch called with non-slice expected value of type %T", expected)
+	}
+	if !tIsList(gotVal) {
+		tb.Fatalf("AssertElementsMatch called with non-slice got value of type %T", got)
+	}
+	equal := reflect.DeepEqual
+	if opts.FmtEqual {
+		equal = tFmtEqual
+	}
+	if missing, extra := tMultisetDiff(expectedVal, gotVal, equal); len(missing) != 0 || len(extra) != 0 {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertElementsMatch failed, len(expected) = %d, len(got) = %d, %s\n%s", expectedVal.Len(), gotVal.Len(), msg, tFormatMultisetDiff(missing, extra))
+		} else {
+			tb.Fatalf("AssertElementsMatch failed, len(expected) = %d, len(got) = %d\n%s", expectedVal.Len(), gotVal.Len(), tFormatMultisetDiff(missing, extra))
+		}
+	}
+}
+
+func tIsList(v reflect.Value) bool {
+	return v.Kind() == reflect.Slice || v.Kind() == reflect.Array
+}
+
+func tFmtEqual(a, b interface{}) bool {
+	return fmt.Sprintf("%v", a) == fmt.Sprintf("%v", b)
+}
+
+// tCountedElem is an element of a multiset and the number of times it occurs.
+// ShowType is set when the value prints like a different element.
+type tCountedElem struct {
+	Val      interface{}
+	Count    int
+	ShowType bool
+}
+
+func (e tCountedElem) String() string {
+	s := fmt.Sprintf("%v", e.Val)
+	if e.ShowType {
+		s = fmt.Sprintf("%T(%v)", e.Val, e.Val)
+	}
+	if e.Count == 1 {
+		return s
+	}
+	return fmt.Sprintf("%s (%d times)", s, e.Count)
+}
+
+// tMultisetDiff returns the elements of expected not matched in got, and
+// the elements of got not matched in expected, grouped by equal value.
+func tMultisetDiff(expected, got reflect.Value, equal func(a, b interface{}) bool) (missing, extra []tCountedElem) {
+	used := make([]bool, got.Len())
+	for i := 0; i < expected.Len(); i++ {
+		e := expected.Index(i).Interface()
+		found := false
+		for j := 0; j < got.Len(); j++ {
+			if !used[j] && equal(e, got.Index(j).Interface()) {
+				used[j], found = true, true
+				break
+			}
+		}
+		if !found {
+			missing = tAddCounted(missing, e, equal)
+		}
+	}
+	for j := 0; j < got.Len(); j++ {
+		if !used[j] {
+			extra = tAddCounted(extra, got.Index(j).Interface(), equal)
+		}
+	}
+	return
+}
+
+func tAddCounted(elems []tCountedElem, v interface{}, equal func(a, b interface{}) bool) []tCountedElem {
+	for i := range elems {
+		if equal(elems[i].Val, v) {
+			elems[i].Count++
+			return elems
+		}
+	}
+	return append(elems, tCountedElem{Val: v, Count: 1})
+}
+
+func tFormatCounted(elems []tCountedElem) string {
+	s := make([]string, len(elems))
+	for i, e := range elems {
+		s[i] = e.String()
+	}
+	return "[" + strings.Join(s, ", ") + "]"
+}
+
+func tFormatMultisetDiff(missing, extra []tCountedElem) string {
+	for i := range missing {
+		for j := range extra {
+			if tFmtEqual(missing[i].Val, extra[j].Val) {
+				missing[i].ShowType, extra[j].ShowType = true, true
+			}
+		}
+	}
+	var lines []string
+	if len(missing) != 0 {
+		lines = append(lines, "\tmissing: "+tFormatCounted(missing))
+	}
+	if len(extra) != 0 {
+		lines = append(lines, "\textra:   "+tFormatCounted(extra))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// AssertSubset checks that every element of sub is in super. Both are
+// slices, arrays or maps (whose keys are the elements); elements are
+// compared with reflect.DeepEqual and counts are ignored.
+func AssertSubset(tb testing.TB, super, sub interface{}, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	superElems, ok := tSetElems(super)
+	if !ok {
+		tb.Fatalf("AssertSubset called with non-slice or map value of type %T", super)
+	}
+	subElems, ok := tSetElems(sub)
+	if !ok {
+		tb.Fatalf("AssertSubset called with non-slice or map value of type %T", sub)
+	}
+	if missing := tSetMinus(subElems, superElems); len(missing) != 0 {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertSubset failed, super = %v, sub = %v, not in super = %v, %s", super, sub, missing, msg)
+		} else {
+			tb.Fatalf("AssertSubset failed, super = %v, sub = %v, not in super = %v", super, sub, missing)
+		}
+	}
+}
+
+// AssertSuperset checks that super holds every element of sub. It is
+// AssertSubset with the arguments swapped, for when super is tested.
+func AssertSuperset(tb testing.TB, sub, super interface{}, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	subElems, ok := tSetElems(sub)
+	if !ok {
+		tb.Fatalf("AssertSuperset called with non-slice or map value of type %T", sub)
+	}
+	superElems, ok := tSetElems(super)
+	if !ok {
+		tb.Fatalf("AssertSuperset called with non-slice or map value of type %T", super)
+	}
+	if missing := tSetMinus(subElems, superElems); len(missing) != 0 {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertSuperset failed, sub = %v, super = %v, not in super = %v, %s", sub, super, missing, msg)
+		} else {
+			tb.Fatalf("AssertSuperset failed, sub = %v, super = %v, not in super = %v", sub, super, missing)
+		}
+	}
+}
+
+func AssertDisjoint(tb testing.TB, a, b interface{}, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	aElems, ok := tSetElems(a)
+	if !ok {
+		tb.Fatalf("AssertDisjoint called with non-slice or map value of type %T", a)
+	}
+	bElems, ok := tSetElems(b)
+	if !ok {
+		tb.Fatalf("AssertDisjoint called with non-slice or map value of type %T", b)
+	}
+	if common := tSetIntersect(aElems, bElems); len(common) != 0 {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertDisjoint failed, a = %v, b = %v, common = %v, %s", a, b, common, msg)
+		} else {
+			tb.Fatalf("AssertDisjoint failed, a = %v, b = %v, common = %v", a, b, common)
+		}
+	}
+}
+
+func AssertIntersects(tb testing.TB, a, b interface{}, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	aElems, ok := tSetElems(a)
+	if !ok {
+		tb.Fatalf("AssertIntersects called with non-slice or map value of type %T", a)
+	}
+	bElems, ok := tSetElems(b)
+	if !ok {
+		tb.Fatalf("AssertIntersects called with non-slice or map value of type %T", b)
+	}
+	if common := tSetIntersect(aElems, bElems); len(common) == 0 {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertIntersects failed, a = %v, b = %v, %s", a, b, msg)
+		} else {
+			tb.Fatalf("AssertIntersects failed, a = %v, b = %v", a, b)
+		}
+	}
+}
+
+// tSetElems returns the elements of a slice or array, or the keys of a map.
+func tSetElems(v interface{}) ([]interface{}, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		elems := make([]interface{}, rv.Len())
+		for i := range elems {
+			elems[i] = rv.Index(i).Interface()
+		}
+		return elems, true
+	case reflect.Map:
+		keys := rv.MapKeys()
+		tSortValues(keys)
+		elems := make([]interface{}, len(keys))
+		for i, key := range keys {
+			elems[i] = key.Interface()
+		}
+		return elems, true
+	}
+	return nil, false
+}
+
+// tSortValues sorts map keys by their %v formatting, for stable messages.
+func tSortValues(keys []reflect.Value) {
+	sort.SliceStable(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+}
+
+func tSetContains(elems []interface{}, v interface{}) bool {
+	for _, e := range elems {
+		if reflect.DeepEqual(e, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// tSetMinus returns the distinct elements of a that are not in b.
+func tSetMinus(a, b []interface{}) []interface{} {
+	var result []interface{}
+	for _, v := range a {
+		if !tSetContains(b, v) && !tSetContains(result, v) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// tSetIntersect returns the distinct elements of a that are also in b.
+func tSetIntersect(a, b []interface{}) []interface{} {
+	var result []interface{}
+	for _, v := range a {
+		if tSetContains(b, v) && !tSetContains(result, v) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,60 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// go test -assert.failed
+
+package assert
+
+import (
+	"testing"
+)
+
+func TestAssertElementsMatch_failed_01(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertElementsMatch(t, []int{1, 2, 2, 3, 3, 3}, []int{3, 1, 4, 2, 7, 4})
+}
+
+func TestAssertElementsMatch_failed_02(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertElementsMatch(t, []interface{}{1, 2}, []interface{}{int64(1), 2})
+}
+
+func TestAssertElementsMatch_fmtEqual_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertElementsMatch(t, []interface{}{1, int64(2), "3"}, []int{3, 2, 2}, FmtEqual)
+}
+
+func TestAssertSubset_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertSubset(t, []int{1, 2, 3, 5, 8}, []int{8, 4, 1, 4, 6})
+}
+
+func TestAssertSuperset_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertSuperset(t, []string{"GET", "HEAD", "OPTIONS"}, map[string]bool{"GET": true, "POST": true})
+}
+
+func TestAssertDisjoint_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertDisjoint(t, []int{1, 2, 3, 5, 8}, []int{2, 4, 6, 8})
+}
+
+func TestAssertIntersects_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertIntersects(t, []int{1, 3, 5}, map[int]string{2: "b", 4: "d"})
+}
@@ -0,0 +1,41 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert_test
+
+import (
+	"testing"
+
+	. "github.com/chai2010/assert"
+)
+
+func TestAssertElementsMatch(t *testing.T) {
+	AssertElementsMatch(t, []int{1, 2, 2, 3}, []int{2, 3, 1, 2})
+	AssertElementsMatch(t, [3]string{"a", "b", "c"}, []string{"c", "a", "b"})
+	AssertElementsMatch(t, []interface{}{1, "1", []int{1}}, []interface{}{[]int{1}, "1", 1})
+	AssertElementsMatch(t, []int{}, []int(nil))
+}
+
+func TestAssertElementsMatch_fmtEqual(t *testing.T) {
+	AssertElementsMatch(t, []interface{}{1, int64(2), "3"}, []int{3, 2, 1}, FmtEqual)
+}
+
+func TestAssertSubset(t *testing.T) {
+	AssertSubset(t, []int{1, 2, 3, 5, 8}, []int{8, 1, 1})
+	AssertSubset(t, map[string]int{"a": 1, "b": 2}, [1]string{"b"})
+	AssertSubset(t, []int{1}, []int{})
+}
+
+func TestAssertSuperset(t *testing.T) {
+	AssertSuperset(t, []string{"GET", "HEAD"}, map[string]bool{"GET": true, "HEAD": true, "POST": true})
+}
+
+func TestAssertDisjoint(t *testing.T) {
+	AssertDisjoint(t, []int{1, 3, 5}, []int{2, 4, 6})
+	AssertDisjoint(t, map[string]int{"a": 1}, []string{"b"})
+}
+
+func TestAssertIntersects(t *testing.T) {
+	AssertIntersects(t, []int{1, 3, 5}, [3]int{5, 6, 7})
+}
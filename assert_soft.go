@@ -0,0 +1,170 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// SoftAssertions collects assertion failures instead of stopping the test.
+// It has the methods of Assertions, whose failures are recorded, and a
+// failure of Do stops only its function.
+//
+// Example:
+//
+//	func TestEndToEnd(t *testing.T) {
+//		s := assert.Soft(t)
+//		defer s.Done()
+//
+//		s.Equal(200, resp.StatusCode)
+//		s.Contains(body, "ok")
+//		s.Do(func(tb testing.TB) {
+//			assert.AssertGreater(tb, count, 0)
+//		})
+//	}
+type SoftAssertions struct {
+	*Assertions
+
+	tb testing.TB
+
+	mu       sync.Mutex
+	failures []tSoftFailure
+	reported int
+}
+
+type tSoftFailure struct {
+	Site string // file:line of the failed assertion
+	Msg  string
+}
+
+// Soft returns a collector of assertion failures for tb. The failures are
+// reported together, with their call sites, by Done or when the test
+// finishes.
+func Soft(tb testing.TB) *SoftAssertions {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	s := &SoftAssertions{tb: tb}
+	s.Assertions = &Assertions{tb: &tSoftTB{TB: tb, s: s}}
+	tb.Cleanup(s.Done)
+	return s
+}
+
+// Failed reports whether an assertion of s has failed.
+func (sa *SoftAssertions) Failed() bool {
+	sa.mu.Lock()
+	defer sa.mu.Unlock()
+	return len(sa.failures) != 0
+}
+
+// Done fails the test with a summary of the failures collected since the
+// last call of Done. It is also called when the test finishes.
+func (sa *SoftAssertions) Done() {
+	if x, ok := sa.tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	sa.mu.Lock()
+	failures := sa.failures[sa.reported:]
+	sa.reported = len(sa.failures)
+	sa.mu.Unlock()
+
+	if len(failures) == 0 {
+		return
+	}
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "Soft assertions failed, %d failures", len(failures))
+	for _, f := range failures {
+		fmt.Fprintf(&buf, "\n\t%s: %s", f.Site, strings.ReplaceAll(f.Msg, "\n", "\n\t\t"))
+	}
+	sa.tb.Errorf("%s", buf.String())
+}
+
+func (sa *SoftAssertions) record(msg string) {
+	sa.mu.Lock()
+	defer sa.mu.Unlock()
+	sa.failures = append(sa.failures, tSoftFailure{Site: tCallSite(), Msg: msg})
+}
+
+// tStop is panicked by a testing.TB wrapper to stop an assertion at
+// FailNow without stopping the test.
+type tStop struct{}
+
+// tRecoverStop recovers a tStop panic. It must be deferred directly.
+func tRecoverStop() {
+	if r := recover(); r != nil {
+		if _, ok := r.(tStop); !ok {
+			panic(r)
+		}
+	}
+}
+
+// tSoftTB is the testing.TB given to the assertions of a SoftAssertions.
+type tSoftTB struct {
+	testing.TB
+	s *SoftAssertions
+}
+
+func (x *tSoftTB) Fail() {
+	x.s.record("failed")
+}
+
+func (x *tSoftTB) FailNow() {
+	x.s.record("failed")
+	panic(tStop{})
+}
+
+func (x *tSoftTB) Error(args ...interface{}) {
+	x.s.record(tSprintln(args...))
+}
+
+func (x *tSoftTB) Errorf(format string, args ...interface{}) {
+	x.s.record(fmt.Sprintf(format, args...))
+}
+
+func (x *tSoftTB) Fatal(args ...interface{}) {
+	x.s.record(tSprintln(args...))
+	panic(tStop{})
+}
+
+func (x *tSoftTB) Fatalf(format string, args ...interface{}) {
+	x.s.record(fmt.Sprintf(format, args...))
+	panic(tStop{})
+}
+
+func (x *tSoftTB) Failed() bool {
+	return x.s.Failed() || x.TB.Failed()
+}
+
+// tSprintln formats args like testing.T.Error.
+func tSprintln(args ...interface{}) string {
+	return strings.TrimSuffix(fmt.Sprintln(args...), "\n")
+}
+
+// tPackageDir is the directory of this package's source files.
+var tPackageDir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Dir(file)
+}()
+
+// tCallSite returns the file:line of the first caller outside the
+// (non-test) source files of this package.
+func tCallSite() string {
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if filepath.Dir(frame.File) != tPackageDir || strings.HasSuffix(frame.File, "_test.go") {
+			return fmt.Sprintf("%s:%d", filepath.Base(frame.File), frame.Line)
+		}
+		if !more {
+			return "???"
+		}
+	}
+}
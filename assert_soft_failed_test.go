@@ -0,0 +1,21 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// go test -assert.failed
+
+package assert
+
+import (
+	"testing"
+)
+
+func TestSoft_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	s := Soft(t)
+	s.Equal(1, 2)
+	s.StringEqual("a\nb\nc", "a\nB\nc")
+	s.Nil(1, "not nil")
+}
@@ -0,0 +1,57 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert_test
+
+import (
+	"testing"
+
+	. "github.com/chai2010/assert"
+)
+
+func TestSoft(t *testing.T) {
+	tb := &tCaptureTB{TB: t}
+	s := Soft(tb)
+	equalAt := tSiteOf(func() { s.Equal(1, 2) })
+	s.Contains("hello, world", "world")
+	httpAt := tSiteOf(func() { s.HTTPStatus(nil, nil, 200) })
+	greaterAt := tSiteOf(func() { s.Do(func(tb testing.TB) { AssertGreater(tb, 1, 2) }) })
+	AssertTrue(t, s.Failed())
+	AssertEqual(t, 0, len(tb.errors))
+
+	s.Done()
+	AssertEqual(t, 1, len(tb.errors))
+	AssertLines(t, tb.errors[0], []string{
+		"Soft assertions failed, 3 failures",
+		"\t" + equalAt + ": AssertEqual failed, expected = 1, got = 2",
+		"\t" + httpAt + ": AssertHTTPStatus called with non-handler value of type <nil>",
+		"\t" + greaterAt + ": AssertGreater failed, expected 1 > 2",
+	})
+
+	s.Done()
+	AssertEqual(t, 1, len(tb.errors))
+}
+
+func TestSoft_stop(t *testing.T) {
+	tb := &tCaptureTB{TB: t}
+	s := Soft(tb)
+	reached := false
+	s.Do(func(tb testing.TB) {
+		tb.FailNow()
+		reached = true
+	})
+	AssertFalse(t, reached)
+	s.Done()
+	AssertEqual(t, 1, len(tb.errors))
+	AssertMatchString(t, `^Soft assertions failed, 1 failures\n\tassert_soft_test\.go:\d+: failed$`, tb.errors[0])
+}
+
+func TestSoft_pass(t *testing.T) {
+	s := Soft(t)
+	defer s.Done()
+
+	s.Equal("a", "a")
+	s.Len([]int{1, 2}, 2)
+	AssertFalse(t, s.Failed())
+}
@@ -0,0 +1,609 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+)
+
+// AssertStringEqual compares two strings exactly. Multi-line strings are
+// reported as a unified diff in which tabs, carriage returns, trailing
+// spaces and other invisible characters are made visible.
+func AssertStringEqual(tb testing.TB, expected, got string, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	if expected != got {
+		if !strings.Contains(expected, "\n") && !strings.Contains(got, "\n") {
+			if msg := fmt.Sprint(args...); msg != "" {
+				tb.Fatalf("AssertStringEqual failed, expected = %q, got = %q, %s", expected, got, msg)
+			} else {
+				tb.Fatalf("AssertStringEqual failed, expected = %q, got = %q", expected, got)
+			}
+			return
+		}
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertStringEqual failed, %s\n%s", msg, tStringDiff(expected, got))
+		} else {
+			tb.Fatalf("AssertStringEqual failed\n%s", tStringDiff(expected, got))
+		}
+	}
+}
+
+// tMultilineStrings reports whether AssertEqual should print a diff: both
+// values are strings and at least one spans several lines.
+func tMultilineStrings(expected, got interface{}) (a, b string, ok bool) {
+	a, aok := expected.(string)
+	b, bok := got.(string)
+	if !aok || !bok {
+		return "", "", false
+	}
+	return a, b, strings.Contains(a, "\n") || strings.Contains(b, "\n")
+}
+
+// tDiffContext is the number of unchanged lines shown around a change.
+const tDiffContext = 3
+
+// tDiffMaxEdits bounds the work of tDiffLines: inputs that differ by more
+// inserted and deleted lines are not diffed.
+const tDiffMaxEdits = 1000
+
+// tLineEdit is one line of a line diff: Op is ' ', '-' or '+', and
+// A and B are the 0-based line indexes in each side (-1 if absent).
+type tLineEdit struct {
+	Op   byte
+	Text string
+	A, B int
+}
+
+// tStringDiff returns a unified diff of two multi-line strings, or both
+// strings quoted if they differ too much for a diff.
+func tStringDiff(expected, got string) string {
+	a, aEOL := tSplitLines(expected)
+	b, bEOL := tSplitLines(got)
+	edits, ok := tDiffLines(a, b)
+	if !ok {
+		return fmt.Sprintf("\texpected = %q\n\tgot = %q", expected, got)
+	}
+
+	var buf strings.Builder
+	buf.WriteString("\t--- expected\n\t+++ got\n")
+	buf.WriteString(tUnifiedDiff(edits, tDiffContext))
+	if aEOL != bEOL {
+		if aEOL {
+			buf.WriteString("\t\\ No newline at end of got\n")
+		} else {
+			buf.WriteString("\t\\ No newline at end of expected\n")
+		}
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// tSplitLines splits s at '\n', keeping any '\r' in the line, and reports
+// whether s ends with a newline.
+func tSplitLines(s string) (lines []string, finalNewline bool) {
+	if s == "" {
+		return nil, false
+	}
+	lines = strings.Split(s, "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1], true
+	}
+	return lines, false
+}
+
+// tDiffLines returns the shortest edit script from a to b (Myers' algorithm),
+// or false if it has more than tDiffMaxEdits insertions and deletions.
+// Common leading and trailing lines are matched first.
+func tDiffLines(a, b []string) ([]tLineEdit, bool) {
+	pre := 0
+	for pre < len(a) && pre < len(b) && a[pre] == b[pre] {
+		pre++
+	}
+	suf := 0
+	for suf < len(a)-pre && suf < len(b)-pre && a[len(a)-1-suf] == b[len(b)-1-suf] {
+		suf++
+	}
+
+	middle, ok := tMyersDiff(a[pre:len(a)-suf], b[pre:len(b)-suf])
+	if !ok {
+		return nil, false
+	}
+	edits := make([]tLineEdit, 0, pre+len(middle)+suf)
+	for i := 0; i < pre; i++ {
+		edits = append(edits, tLineEdit{Op: ' ', Text: a[i], A: i, B: i})
+	}
+	for _, e := range middle {
+		if e.A >= 0 {
+			e.A += pre
+		}
+		if e.B >= 0 {
+			e.B += pre
+		}
+		edits = append(edits, e)
+	}
+	for i := suf; i > 0; i-- {
+		edits = append(edits, tLineEdit{Op: ' ', Text: a[len(a)-i], A: len(a) - i, B: len(b) - i})
+	}
+	return edits, true
+}
+
+// tMyersDiff is the core of tDiffLines. trace[d] keeps the furthest x
+// of the diagonals -d-1 to d+1 before step d, so memory grows with the
+// square of the edit distance, which is bounded by tDiffMaxEdits.
+func tMyersDiff(a, b []string) ([]tLineEdit, bool) {
+	n, m := len(a), len(b)
+	maxEdits := n + m
+	if maxEdits > tDiffMaxEdits {
+		maxEdits = tDiffMaxEdits
+	}
+	offset := maxEdits + 1
+	v := make([]int, 2*maxEdits+3)
+	var trace [][]int
+
+	for d := 0; d <= maxEdits; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d-1:offset+d+2]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x, y = x+1, y+1
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return tBacktrackDiff(a, b, trace, d), true
+			}
+		}
+	}
+	return nil, false
+}
+
+func tBacktrackDiff(a, b []string, trace [][]int, d int) []tLineEdit {
+	var edits []tLineEdit
+	x, y := len(a), len(b)
+	for ; d >= 0; d-- {
+		v, offset := trace[d], d+1
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x, y = x-1, y-1
+			edits = append(edits, tLineEdit{Op: ' ', Text: a[x], A: x, B: y})
+		}
+		if d == 0 {
+			break
+		}
+		if x == prevX {
+			y--
+			edits = append(edits, tLineEdit{Op: '+', Text: b[y], A: -1, B: y})
+		} else {
+			x--
+			edits = append(edits, tLineEdit{Op: '-', Text: a[x], A: x, B: -1})
+		}
+	}
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
+
+// tUnifiedDiff formats edits as unified diff hunks, one tab-indented line
+// per diff line, with context unchanged lines around each change.
+func tUnifiedDiff(edits []tLineEdit, context int) string {
+	var buf strings.Builder
+	for i := 0; i < len(edits); {
+		if edits[i].Op == ' ' {
+			i++
+			continue
+		}
+
+		// Extend the hunk while changes are closer than 2*context lines.
+		start := i - context
+		if start < 0 {
+			start = 0
+		}
+		end := i
+		for j := i; j < len(edits); j++ {
+			if edits[j].Op != ' ' {
+				end = j + 1
+			} else if j-end >= 2*context {
+				break
+			}
+		}
+		end += context
+		if end > len(edits) {
+			end = len(edits)
+		}
+
+		aStart, aCount, bStart, bCount := 0, 0, 0, 0
+		for j := start; j < end; j++ {
+			e := edits[j]
+			if e.Op != '+' {
+				if aCount == 0 {
+					aStart = e.A + 1
+				}
+				aCount++
+			}
+			if e.Op != '-' {
+				if bCount == 0 {
+					bStart = e.B + 1
+				}
+				bCount++
+			}
+		}
+		if aCount == 0 {
+			aStart = tDiffLineBefore(edits, start, true)
+		}
+		if bCount == 0 {
+			bStart = tDiffLineBefore(edits, start, false)
+		}
+		fmt.Fprintf(&buf, "\t@@ -%d,%d +%d,%d @@\n", aStart, aCount, bStart, bCount)
+		for j := start; j < end; j++ {
+			fmt.Fprintf(&buf, "\t%c%s\n", edits[j].Op, tVisibleLine(edits[j].Text))
+		}
+		i = end
+	}
+	return buf.String()
+}
+
+// tDiffLineBefore returns the 1-based number of the last line of one side
+// before edits[start], as used in an empty hunk range.
+func tDiffLineBefore(edits []tLineEdit, start int, a bool) int {
+	for j := start - 1; j >= 0; j-- {
+		if a && edits[j].A >= 0 {
+			return edits[j].A + 1
+		}
+		if !a && edits[j].B >= 0 {
+			return edits[j].B + 1
+		}
+	}
+	return 0
+}
+
+// tVisibleLine makes invisible characters in a line visible: a tab is shown
+// as '→', a trailing space as '·', a carriage return as '␍', and other
+// non-printing characters (such as U+200B ZERO WIDTH SPACE) as <U+XXXX>.
+func tVisibleLine(s string) string {
+	trimmed := strings.TrimRight(s, " \t\r")
+	var buf strings.Builder
+	for i, r := range s {
+		switch {
+		case r == '\t':
+			buf.WriteRune('→')
+		case r == '\r':
+			buf.WriteRune('␍')
+		case r == ' ' && i >= len(trimmed):
+			buf.WriteRune('·')
+		case r == ' ' || unicode.IsPrint(r):
+			buf.WriteRune(r)
+		default:
+			fmt.Fprintf(&buf, "<%U>", r)
+		}
+	}
+	return buf.String()
+}
+
+func AssertContains(tb testing.TB, s interface{}, substr string, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	str, ok := tStringOf(s)
+	if !ok {
+		tb.Fatalf("AssertContains called with non-string value of type %T", s)
+	}
+	if !strings.Contains(str, substr) {
+		start, n := tClosestMatch(str, substr)
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertContains failed, s = %q, substr = %q, %s\n%s", str, substr, msg, tHighlightClosest(str, start, n, start+n))
+		} else {
+			tb.Fatalf("AssertContains failed, s = %q, substr = %q\n%s", str, substr, tHighlightClosest(str, start, n, start+n))
+		}
+	}
+}
+
+func AssertNotContains(tb testing.TB, s interface{}, substr string, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	str, ok := tStringOf(s)
+	if !ok {
+		tb.Fatalf("AssertNotContains called with non-string value of type %T", s)
+	}
+	if i := strings.Index(str, substr); i >= 0 {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertNotContains failed, s = %q, substr = %q, %s\n%s", str, substr, msg, tHighlight(str, i, len(substr), -1, "match"))
+		} else {
+			tb.Fatalf("AssertNotContains failed, s = %q, substr = %q\n%s", str, substr, tHighlight(str, i, len(substr), -1, "match"))
+		}
+	}
+}
+
+func AssertHasPrefix(tb testing.TB, s interface{}, prefix string, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	str, ok := tStringOf(s)
+	if !ok {
+		tb.Fatalf("AssertHasPrefix called with non-string value of type %T", s)
+	}
+	if !strings.HasPrefix(str, prefix) {
+		n := tCommonPrefixLen(str, prefix)
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertHasPrefix failed, s = %q, prefix = %q, %s\n%s", str, prefix, msg, tHighlightClosest(str, 0, n, n))
+		} else {
+			tb.Fatalf("AssertHasPrefix failed, s = %q, prefix = %q\n%s", str, prefix, tHighlightClosest(str, 0, n, n))
+		}
+	}
+}
+
+func AssertHasSuffix(tb testing.TB, s interface{}, suffix string, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	str, ok := tStringOf(s)
+	if !ok {
+		tb.Fatalf("AssertHasSuffix called with non-string value of type %T", s)
+	}
+	if !strings.HasSuffix(str, suffix) {
+		n := tCommonSuffixLen(str, suffix)
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertHasSuffix failed, s = %q, suffix = %q, %s\n%s", str, suffix, msg, tHighlightClosest(str, len(str)-n, n, tPrevRuneStart(str, len(str)-n)))
+		} else {
+			tb.Fatalf("AssertHasSuffix failed, s = %q, suffix = %q\n%s", str, suffix, tHighlightClosest(str, len(str)-n, n, tPrevRuneStart(str, len(str)-n)))
+		}
+	}
+}
+
+func AssertEqualFold(tb testing.TB, expected, got interface{}, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	a, ok := tStringOf(expected)
+	if !ok {
+		tb.Fatalf("AssertEqualFold called with non-string expected value of type %T", expected)
+	}
+	b, ok := tStringOf(got)
+	if !ok {
+		tb.Fatalf("AssertEqualFold called with non-string got value of type %T", got)
+	}
+	if !strings.EqualFold(a, b) {
+		n := tCommonFoldPrefixLen(b, a)
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertEqualFold failed, expected = %q, got = %q, %s\n%s", a, b, msg, tHighlightClosest(b, 0, n, n))
+		} else {
+			tb.Fatalf("AssertEqualFold failed, expected = %q, got = %q\n%s", a, b, tHighlightClosest(b, 0, n, n))
+		}
+	}
+}
+
+func AssertCount(tb testing.TB, s interface{}, substr string, n int, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	str, ok := tStringOf(s)
+	if !ok {
+		tb.Fatalf("AssertCount called with non-string value of type %T", s)
+	}
+	if count := strings.Count(str, substr); count != n {
+		var offsets []int
+		if substr != "" {
+			for i := 0; ; {
+				j := strings.Index(str[i:], substr)
+				if j < 0 {
+					break
+				}
+				offsets = append(offsets, i+j)
+				i += j + len(substr)
+			}
+		}
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertCount failed, substr = %q, expected = %d, got = %d, offsets = %v, %s", substr, n, count, offsets, msg)
+		} else {
+			tb.Fatalf("AssertCount failed, substr = %q, expected = %d, got = %d, offsets = %v", substr, n, count, offsets)
+		}
+	}
+}
+
+// AssertLines compares the lines of got with expectedLines. A final newline
+// in got does not start an extra line.
+func AssertLines(tb testing.TB, got interface{}, expectedLines []string, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	str, ok := tStringOf(got)
+	if !ok {
+		tb.Fatalf("AssertLines called with non-string value of type %T", got)
+	}
+	gotLines, _ := tSplitLines(str)
+	edits, ok := tDiffLines(expectedLines, gotLines)
+	if !ok {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertLines failed, expected = %q, got = %q, %s", expectedLines, gotLines, msg)
+		} else {
+			tb.Fatalf("AssertLines failed, expected = %q, got = %q", expectedLines, gotLines)
+		}
+		return
+	}
+	for _, e := range edits {
+		if e.Op != ' ' {
+			diff := strings.TrimSuffix("\t--- expected\n\t+++ got\n"+tUnifiedDiff(edits, tDiffContext), "\n")
+			if msg := fmt.Sprint(args...); msg != "" {
+				tb.Fatalf("AssertLines failed, %s\n%s", msg, diff)
+			} else {
+				tb.Fatalf("AssertLines failed\n%s", diff)
+			}
+			return
+		}
+	}
+}
+
+// tStringOf returns the text of a string, []byte or fmt.Stringer.
+func tStringOf(v interface{}) (string, bool) {
+	switch x := v.(type) {
+	case string:
+		return x, true
+	case []byte:
+		return string(x), true
+	case fmt.Stringer:
+		return x.String(), true
+	}
+	return "", false
+}
+
+// tClosestMatch finds the longest prefix of substr that occurs in s and
+// returns where it starts and its length in bytes.
+func tClosestMatch(s, substr string) (start, n int) {
+	for n = len(substr); n > 0; n-- {
+		if n < len(substr) && !utf8.RuneStart(substr[n]) {
+			continue
+		}
+		if i := strings.Index(s, substr[:n]); i >= 0 {
+			return i, n
+		}
+	}
+	return 0, 0
+}
+
+func tCommonPrefixLen(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	for n > 0 && n < len(a) && !utf8.RuneStart(a[n]) {
+		n--
+	}
+	return n
+}
+
+func tCommonSuffixLen(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[len(a)-1-n] == b[len(b)-1-n] {
+		n++
+	}
+	for n > 0 && n < len(a) && !utf8.RuneStart(a[len(a)-n]) {
+		n--
+	}
+	return n
+}
+
+// tCommonFoldPrefixLen returns the length in bytes of the longest prefix of
+// a that equals a prefix of b under Unicode case folding.
+func tCommonFoldPrefixLen(a, b string) int {
+	n := 0
+	for n < len(a) && len(b) > 0 {
+		ra, sa := utf8.DecodeRuneInString(a[n:])
+		rb, sb := utf8.DecodeRuneInString(b)
+		if !strings.EqualFold(string(ra), string(rb)) {
+			break
+		}
+		n += sa
+		b = b[sb:]
+	}
+	return n
+}
+
+// tHighlightContext is the number of bytes shown around a highlighted part.
+const tHighlightContext = 24
+
+// tHighlight shows the part of s around s[start:start+n], underlining that
+// part with '~' and marking the character at byte offset mismatch with '^'
+// (a mismatch at len(s) is marked after the end, -1 marks nothing). Both
+// offsets are given in the text.
+func tHighlight(s string, start, n, mismatch int, label string) string {
+	from, to := start-tHighlightContext, start+n+tHighlightContext
+	if from < 0 {
+		from = 0
+	}
+	if to > len(s) {
+		to = len(s)
+	}
+	for from > 0 && !utf8.RuneStart(s[from]) {
+		from--
+	}
+	for to < len(s) && !utf8.RuneStart(s[to]) {
+		to++
+	}
+
+	var text, mark strings.Builder
+	if from > 0 {
+		text.WriteString("...")
+		mark.WriteString("   ")
+	}
+	for i, r := range s[from:to] {
+		i += from
+		v := tVisibleRune(r)
+		c := " "
+		switch {
+		case i >= start && i < start+n:
+			c = "~"
+		case i == mismatch:
+			c = "^"
+		}
+		text.WriteString(v)
+		mark.WriteString(strings.Repeat(c, utf8.RuneCountInString(v)))
+	}
+	if to < len(s) {
+		text.WriteString("...")
+	} else if mismatch == len(s) {
+		mark.WriteString("^")
+	}
+	at := fmt.Sprintf("offset %d", start)
+	if mismatch >= 0 {
+		at += fmt.Sprintf(", mismatch at offset %d", mismatch)
+	}
+	return fmt.Sprintf("\t%s at %s:\n\t\t%s\n\t\t%s", label, at, text.String(), strings.TrimRight(mark.String(), " "))
+}
+
+// tHighlightClosest is tHighlight for the closest match of a failed search,
+// which may be empty.
+func tHighlightClosest(s string, start, n, mismatch int) string {
+	if n == 0 && s != "" {
+		return "\tno partial match"
+	}
+	return tHighlight(s, start, n, mismatch, "closest match")
+}
+
+// tPrevRuneStart returns the offset of the character before s[i:], or -1.
+func tPrevRuneStart(s string, i int) int {
+	if i <= 0 {
+		return -1
+	}
+	_, size := utf8.DecodeLastRuneInString(s[:i])
+	return i - size
+}
+
+// tVisibleRune renders one character for tHighlight, so that every character
+// takes a known number of columns.
+func tVisibleRune(r rune) string {
+	switch {
+	case r == '\t':
+		return "→"
+	case r == '\r':
+		return "␍"
+	case r == '\n':
+		return "↵"
+	case r == ' ' || unicode.IsPrint(r):
+		return string(r)
+	default:
+		return fmt.Sprintf("<%U>", r)
+	}
+}
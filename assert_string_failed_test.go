@@ -0,0 +1,105 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// go test -assert.failed
+
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+const tStringDiffText = `package main
+
+import "fmt"
+
+func main() {
+	fmt.Println("hello")
+}
+
+// one
+// two
+// three
+// four
+// five
+// six
+// seven
+// eight
+`
+
+func TestAssertStringEqual_failed_01(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertStringEqual(t, "abc", "abd")
+}
+
+func TestAssertStringEqual_failed_02(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	got := strings.Replace(tStringDiffText, "// two\n", "// two  \n", 1)
+	got = strings.Replace(got, "// five\n", "// fiv\u200be\r\n", 1)
+	got = strings.TrimSuffix(got, "\n")
+	AssertStringEqual(t, tStringDiffText, got)
+}
+
+func TestAssertStringEqual_failed_03(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	got := strings.Replace(tStringDiffText, "\t", "    ", 1)
+	got = strings.Replace(got, "// eight\n", "// eight\n// nine\n", 1)
+	AssertEqual(t, tStringDiffText, got)
+}
+
+func TestAssertContains_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertContains(t, "the quick brown fox jumps over the lazy dog", "fox\tjumps")
+}
+
+func TestAssertNotContains_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertNotContains(t, []byte("the quick brown fox jumps over the lazy dog"), "fox")
+}
+
+func TestAssertHasPrefix_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertHasPrefix(t, "assert.go", "assets")
+}
+
+func TestAssertHasSuffix_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertHasSuffix(t, "assert_test.go", "_failed_test.go")
+}
+
+func TestAssertEqualFold_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertEqualFold(t, "Content-Type", "content_type")
+}
+
+func TestAssertCount_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertCount(t, "cheese", "e", 2)
+}
+
+func TestAssertLines_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertLines(t, "a\nb\nc\n", []string{"a", "c", "d"})
+}
@@ -0,0 +1,100 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestStringDiff(t *testing.T) {
+	var a []string
+	for i := 1; i <= 20; i++ {
+		a = append(a, fmt.Sprintf("line %d", i))
+	}
+	b := append([]string(nil), a...)
+	b[2] = "line three"  // merged with the next change
+	b[7] = "line eight"  // 5 lines later
+	b[17] = "line 18\t " // a separate hunk
+
+	expected := strings.Join(a, "\n") + "\n"
+	got := strings.Join(b, "\n")
+	AssertEqual(t, strings.Join([]string{
+		"\t--- expected",
+		"\t+++ got",
+		"\t@@ -1,11 +1,11 @@",
+		"\t line 1",
+		"\t line 2",
+		"\t-line 3",
+		"\t+line three",
+		"\t line 4",
+		"\t line 5",
+		"\t line 6",
+		"\t line 7",
+		"\t-line 8",
+		"\t+line eight",
+		"\t line 9",
+		"\t line 10",
+		"\t line 11",
+		"\t@@ -15,6 +15,6 @@",
+		"\t line 15",
+		"\t line 16",
+		"\t line 17",
+		"\t-line 18",
+		"\t+line 18→·",
+		"\t line 19",
+		"\t line 20",
+		"\t\\ No newline at end of got",
+	}, "\n"), tStringDiff(expected, got))
+}
+
+func TestStringDiff_insertOnly(t *testing.T) {
+	AssertEqual(t, strings.Join([]string{
+		"\t--- expected",
+		"\t+++ got",
+		"\t@@ -1,2 +1,3 @@",
+		"\t a",
+		"\t+b",
+		"\t c",
+	}, "\n"), tStringDiff("a\nc\n", "a\nb\nc\n"))
+}
+
+func TestStringDiff_tooLarge(t *testing.T) {
+	var a, b []string
+	for i := 0; i < 3000; i++ {
+		a = append(a, fmt.Sprintf("a%d", i))
+		b = append(b, fmt.Sprintf("b%d", i))
+	}
+	_, ok := tDiffLines(a, b)
+	AssertFalse(t, ok)
+
+	diff := tStringDiff("x\ny", "x\nz")
+	AssertHasPrefix(t, diff, "\t--- expected")
+	diff = tStringDiff(strings.Join(a, "\n"), strings.Join(b, "\n"))
+	AssertHasPrefix(t, diff, "\texpected = \"a0\\na1")
+}
+
+func TestDiffLines_commonEnds(t *testing.T) {
+	a := make([]string, 10000)
+	b := make([]string, 10000)
+	for i := range a {
+		a[i] = fmt.Sprint(i)
+		b[i] = a[i]
+	}
+	b[5000] = "changed"
+	edits, ok := tDiffLines(a, b)
+	AssertTrue(t, ok)
+	AssertEqual(t, 10001, len(edits))
+	AssertEqual(t, tLineEdit{Op: '-', Text: "5000", A: 5000, B: -1}, edits[5000])
+	AssertEqual(t, tLineEdit{Op: '+', Text: "changed", A: -1, B: 5000}, edits[5001])
+	AssertEqual(t, tLineEdit{Op: ' ', Text: "9999", A: 9999, B: 9999}, edits[10000])
+}
+
+func TestHighlightClosest(t *testing.T) {
+	AssertEqual(t, "\tno partial match", tHighlightClosest("abc", 0, 0, 0))
+	AssertEqual(t, "\tno partial match", tHighlightClosest("abc", 3, 0, 2))
+	AssertEqual(t, "\tclosest match at offset 0, mismatch at offset 1:\n\t\tabc\n\t\t~^", tHighlightClosest("abc", 0, 1, 1))
+}
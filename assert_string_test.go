@@ -0,0 +1,66 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/chai2010/assert"
+)
+
+func TestAssertStringEqual(t *testing.T) {
+	AssertStringEqual(t, "abc", strings.ToLower("ABC"))
+	AssertStringEqual(t, "line1\nline2\n", strings.Join([]string{"line1", "line2", ""}, "\n"))
+}
+
+type tStringer string
+
+func (s tStringer) String() string { return string(s) }
+
+func TestAssertContains(t *testing.T) {
+	AssertContains(t, "hello, world", "o, w")
+	AssertContains(t, []byte("hello, world"), "hello")
+	AssertContains(t, tStringer("hello, world"), "")
+}
+
+func TestAssertNotContains(t *testing.T) {
+	AssertNotContains(t, "hello, world", "World")
+	AssertNotContains(t, []byte("hello, world"), "hello!")
+}
+
+func TestAssertHasPrefix(t *testing.T) {
+	AssertHasPrefix(t, "assert.go", "assert")
+	AssertHasPrefix(t, tStringer("assert.go"), "")
+}
+
+func TestAssertHasSuffix(t *testing.T) {
+	AssertHasSuffix(t, []byte("assert.go"), ".go")
+
+	tb := &tCaptureTB{TB: t}
+	AssertHasSuffix(tb, "assert.go", "_test.go")
+	AssertEqual(t, []string{
+		"AssertHasSuffix failed, s = \"assert.go\", suffix = \"_test.go\"\n" +
+			"\tclosest match at offset 5, mismatch at offset 4:\n" +
+			"\t\tassert.go\n" +
+			"\t\t    ^~~~~",
+	}, tb.errors)
+}
+
+func TestAssertEqualFold(t *testing.T) {
+	AssertEqualFold(t, "Go", "GO")
+	AssertEqualFold(t, "straße", []byte("STRAßE"))
+}
+
+func TestAssertCount(t *testing.T) {
+	AssertCount(t, "cheese", "e", 3)
+	AssertCount(t, "five", "", 5)
+}
+
+func TestAssertLines(t *testing.T) {
+	AssertLines(t, "a\nb\nc\n", []string{"a", "b", "c"})
+	AssertLines(t, []byte("a\r\nb"), []string{"a\r", "b"})
+	AssertLines(t, "", nil)
+}
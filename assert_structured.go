@@ -0,0 +1,447 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/gob"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// StructuredFormat decodes an encoded document into a generic tree made of
+// nil, bool, int64, float64, string, []interface{} and map[string]interface{}.
+type StructuredFormat interface {
+	Name() string
+	Decode(data []byte) (interface{}, error)
+}
+
+var (
+	FormatJSON StructuredFormat = tJSONFormat{}
+	FormatXML  StructuredFormat = tXMLFormat{}
+	FormatCSV  StructuredFormat = tCSVFormat{}
+)
+
+// FormatGob returns a gob format. Gob streams can only be decoded into a
+// concrete Go type, so prototype gives the type (a value or a pointer) the
+// data was encoded from.
+func FormatGob(prototype interface{}) StructuredFormat {
+	typ := reflect.TypeOf(prototype)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return tGobFormat{typ: typ}
+}
+
+func AssertStructuredEqual(tb testing.TB, expected, got []byte, format StructuredFormat, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	expectedTree, err := format.Decode(expected)
+	if err != nil {
+		tb.Fatalf("AssertStructuredEqual called with invalid %s expected value, err = %v", format.Name(), err)
+	}
+	gotTree, err := format.Decode(got)
+	if err != nil {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertStructuredEqual failed, format = %s, err = %v, %s", format.Name(), err, msg)
+		} else {
+			tb.Fatalf("AssertStructuredEqual failed, format = %s, err = %v", format.Name(), err)
+		}
+		return
+	}
+	if diffs := tDiffTree(expectedTree, gotTree); len(diffs) != 0 {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertStructuredEqual failed, format = %s, %s\n%s", format.Name(), msg, tFormatTreeDiffs(diffs))
+		} else {
+			tb.Fatalf("AssertStructuredEqual failed, format = %s\n%s", format.Name(), tFormatTreeDiffs(diffs))
+		}
+	}
+}
+
+type tJSONFormat struct{}
+
+func (tJSONFormat) Name() string { return "json" }
+
+func (tJSONFormat) Decode(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("json: unexpected data after top-level value")
+	}
+	return tNormalizeJSON(v), nil
+}
+
+// tNormalizeJSON turns json.Number leaves into int64 or float64, so that
+// "1" and "1.0" compare equal while large integers keep full precision.
+func tNormalizeJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		if f, err := v.Float64(); err == nil {
+			return tTreeFloat(f)
+		}
+		return v.String()
+	case []interface{}:
+		for i := range v {
+			v[i] = tNormalizeJSON(v[i])
+		}
+		return v
+	case map[string]interface{}:
+		for k := range v {
+			v[k] = tNormalizeJSON(v[k])
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+type tXMLFormat struct{}
+
+func (tXMLFormat) Name() string { return "xml" }
+
+// Decode maps every element to a tree node: attributes become "@name" keys,
+// non-blank character data becomes "#text", and child elements are keyed by
+// their local name (a list when the name repeats). An element holding only
+// text is collapsed to the text itself. The root is keyed by its name.
+func (tXMLFormat) Decode(data []byte) (interface{}, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil, fmt.Errorf("xml: no root element")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			root, err := tDecodeXMLElement(dec, start)
+			if err != nil {
+				return nil, err
+			}
+			if err := tCheckXMLEnd(dec); err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{start.Name.Local: root}, nil
+		}
+	}
+}
+
+// tCheckXMLEnd checks that only comments, processing instructions and
+// white space follow the root element.
+func tCheckXMLEnd(dec *xml.Decoder) error {
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch tok := tok.(type) {
+		case xml.Comment, xml.ProcInst:
+		case xml.CharData:
+			if len(bytes.TrimSpace(tok)) != 0 {
+				return fmt.Errorf("xml: unexpected data after root element")
+			}
+		default:
+			return fmt.Errorf("xml: unexpected data after root element")
+		}
+	}
+}
+
+func tDecodeXMLElement(dec *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	node := make(map[string]interface{})
+	for _, attr := range start.Attr {
+		node["@"+attr.Name.Local] = attr.Value
+	}
+
+	var text strings.Builder
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			child, err := tDecodeXMLElement(dec, tok)
+			if err != nil {
+				return nil, err
+			}
+			// child is never a list itself, so a list marks a repeated name
+			name := tok.Name.Local
+			switch prev := node[name].(type) {
+			case nil:
+				node[name] = child
+			case []interface{}:
+				node[name] = append(prev, child)
+			default:
+				node[name] = []interface{}{prev, child}
+			}
+		case xml.CharData:
+			text.Write(tok)
+		case xml.EndElement:
+			s := strings.TrimSpace(text.String())
+			if len(node) == 0 {
+				return s, nil
+			}
+			if s != "" {
+				node["#text"] = s
+			}
+			return node, nil
+		}
+	}
+}
+
+type tCSVFormat struct{}
+
+func (tCSVFormat) Name() string { return "csv" }
+
+func (tCSVFormat) Decode(data []byte) (interface{}, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]interface{}, len(records))
+	for i, record := range records {
+		row := make([]interface{}, len(record))
+		for j, cell := range record {
+			row[j] = cell
+		}
+		rows[i] = row
+	}
+	return rows, nil
+}
+
+type tGobFormat struct {
+	typ reflect.Type
+}
+
+func (f tGobFormat) Name() string { return "gob" }
+
+func (f tGobFormat) Decode(data []byte) (interface{}, error) {
+	if f.typ == nil {
+		return nil, fmt.Errorf("gob: nil prototype")
+	}
+	v := reflect.New(f.typ)
+	if err := gob.NewDecoder(bytes.NewReader(data)).DecodeValue(v); err != nil {
+		return nil, err
+	}
+	return tValueTree(v.Elem()), nil
+}
+
+// tValueTree converts a Go value into the generic tree used by tDiffTree.
+// Struct fields are keyed by name and only exported fields are kept.
+func tValueTree(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return nil
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return tValueTree(v.Elem())
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if u := v.Uint(); u <= 1<<63-1 {
+			return int64(u)
+		}
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return tTreeFloat(v.Float())
+	case reflect.String:
+		return v.String()
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return []interface{}{}
+		}
+		list := make([]interface{}, v.Len())
+		for i := range list {
+			list[i] = tValueTree(v.Index(i))
+		}
+		return list
+	case reflect.Map:
+		m := make(map[string]interface{}, v.Len())
+		for _, key := range v.MapKeys() {
+			m[fmt.Sprint(key.Interface())] = tValueTree(v.MapIndex(key))
+		}
+		return m
+	case reflect.Struct:
+		m := make(map[string]interface{})
+		for i := 0; i < v.NumField(); i++ {
+			if field := v.Type().Field(i); field.PkgPath == "" {
+				m[field.Name] = tValueTree(v.Field(i))
+			}
+		}
+		return m
+	default:
+		if v.CanInterface() {
+			return fmt.Sprint(v.Interface())
+		}
+		return v.String()
+	}
+}
+
+// tTreeFloat stores integral floats as int64 so that they compare equal to
+// the same number decoded as an integer.
+func tTreeFloat(f float64) interface{} {
+	if f == math.Trunc(f) && math.Abs(f) < 1<<63 {
+		return int64(f)
+	}
+	return f
+}
+
+// tTreeDiff is one difference found by tDiffTree.
+type tTreeDiff struct {
+	Path     string
+	Expected interface{}
+	Got      interface{}
+	Reason   string
+}
+
+func (d tTreeDiff) String() string {
+	switch d.Reason {
+	case "missing":
+		return fmt.Sprintf("%s: missing, expected = %s", d.Path, tTreeString(d.Expected))
+	case "extra":
+		return fmt.Sprintf("%s: unexpected, got = %s", d.Path, tTreeString(d.Got))
+	case "":
+		return fmt.Sprintf("%s: expected = %s, got = %s", d.Path, tTreeString(d.Expected), tTreeString(d.Got))
+	default:
+		return fmt.Sprintf("%s: %s, expected = %s, got = %s", d.Path, d.Reason, tTreeString(d.Expected), tTreeString(d.Got))
+	}
+}
+
+// tDiffTree compares two generic trees and returns every difference, with
+// paths of the form $.key[index]. Leaves are compared the way AssertEqual
+// compares values, by their %v formatting.
+func tDiffTree(expected, got interface{}) []tTreeDiff {
+	var diffs []tTreeDiff
+	tDiffTreeAt("$", expected, got, &diffs)
+	return diffs
+}
+
+func tDiffTreeAt(path string, expected, got interface{}, diffs *[]tTreeDiff) {
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		g, ok := got.(map[string]interface{})
+		if !ok {
+			*diffs = append(*diffs, tTreeDiff{Path: path, Expected: expected, Got: got, Reason: "type mismatch"})
+			return
+		}
+		for _, k := range tSortedTreeKeys(e, g) {
+			ev, eok := e[k]
+			gv, gok := g[k]
+			switch {
+			case !gok:
+				*diffs = append(*diffs, tTreeDiff{Path: tTreeKeyPath(path, k), Expected: ev, Reason: "missing"})
+			case !eok:
+				*diffs = append(*diffs, tTreeDiff{Path: tTreeKeyPath(path, k), Got: gv, Reason: "extra"})
+			default:
+				tDiffTreeAt(tTreeKeyPath(path, k), ev, gv, diffs)
+			}
+		}
+	case []interface{}:
+		g, ok := got.([]interface{})
+		if !ok {
+			*diffs = append(*diffs, tTreeDiff{Path: path, Expected: expected, Got: got, Reason: "type mismatch"})
+			return
+		}
+		for i := 0; i < len(e) || i < len(g); i++ {
+			elemPath := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(g):
+				*diffs = append(*diffs, tTreeDiff{Path: elemPath, Expected: e[i], Reason: "missing"})
+			case i >= len(e):
+				*diffs = append(*diffs, tTreeDiff{Path: elemPath, Got: g[i], Reason: "extra"})
+			default:
+				tDiffTreeAt(elemPath, e[i], g[i], diffs)
+			}
+		}
+	default:
+		switch got.(type) {
+		case map[string]interface{}, []interface{}:
+			*diffs = append(*diffs, tTreeDiff{Path: path, Expected: expected, Got: got, Reason: "type mismatch"})
+			return
+		}
+		if fmt.Sprintf("%v", expected) != fmt.Sprintf("%v", got) {
+			*diffs = append(*diffs, tTreeDiff{Path: path, Expected: expected, Got: got})
+		}
+	}
+}
+
+func tSortedTreeKeys(a, b map[string]interface{}) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func tTreeKeyPath(path, key string) string {
+	for _, r := range key {
+		if !(r == '_' || r == '-' || r == '@' || r == '#' ||
+			'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9') {
+			return fmt.Sprintf("%s[%s]", path, strconv.Quote(key))
+		}
+	}
+	if key == "" {
+		return path + `[""]`
+	}
+	return path + "." + key
+}
+
+func tTreeString(v interface{}) string {
+	switch x := v.(type) {
+	case string:
+		return strconv.Quote(x)
+	case map[string]interface{}, []interface{}:
+		if data, err := json.Marshal(v); err == nil {
+			return string(data)
+		}
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+func tFormatTreeDiffs(diffs []tTreeDiff) string {
+	var buf strings.Builder
+	for i, d := range diffs {
+		if i > 0 {
+			buf.WriteByte('\n')
+		}
+		buf.WriteString("\t")
+		buf.WriteString(d.String())
+	}
+	return buf.String()
+}
@@ -0,0 +1,68 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// go test -assert.failed
+
+package assert
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+)
+
+func TestAssertStructuredEqual_failed_json(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertStructuredEqual(t,
+		[]byte(`{"name": "api", "port": 80, "tags": ["a", "b"], "tls": {"cert": "a.pem"}}`),
+		[]byte(`{"name": "api", "port": 8080, "tags": ["a"], "debug": true, "tls": "off"}`),
+		FormatJSON,
+	)
+}
+
+func TestAssertStructuredEqual_failed_xml(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertStructuredEqual(t,
+		[]byte(`<config name="api"><port>80</port><tag>a</tag><tag>b</tag></config>`),
+		[]byte(`<config name="web"><port>80</port><tag>a</tag></config>`),
+		FormatXML,
+	)
+}
+
+func TestAssertStructuredEqual_failed_csv(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertStructuredEqual(t,
+		[]byte("name,port\napi,80\n"),
+		[]byte("name,port\napi,8080\nweb,80\n"),
+		FormatCSV,
+	)
+}
+
+func TestAssertStructuredEqual_failed_gob(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	type config struct {
+		Name string
+		Port int
+	}
+	encode := func(v interface{}) []byte {
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+	AssertStructuredEqual(t,
+		encode(config{Name: "api", Port: 80}),
+		encode(config{Name: "api", Port: 8080}),
+		FormatGob(config{}),
+	)
+}
@@ -0,0 +1,75 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	. "github.com/chai2010/assert"
+)
+
+type tStructuredConfig struct {
+	Name    string
+	Port    int
+	Tags    []string
+	Limits  map[string]float64
+	Backend *tStructuredConfig
+}
+
+func TestAssertStructuredEqual_json(t *testing.T) {
+	AssertStructuredEqual(t,
+		[]byte(`{"name": "api", "port": 80, "tags": ["a", "b"], "ratio": 1.0}`),
+		[]byte(`{"ratio": 1, "tags": ["a", "b"], "port": 80, "name": "api"}`),
+		FormatJSON,
+	)
+}
+
+func TestAssertStructuredEqual_xml(t *testing.T) {
+	AssertStructuredEqual(t,
+		[]byte(`<config name="api"><port>80</port><tag>a</tag><tag>b</tag></config>`),
+		[]byte(`<?xml version="1.0"?>
+<config name="api">
+	<tag>a</tag>
+	<tag>b</tag>
+	<port> 80 </port>
+</config>`),
+		FormatXML,
+	)
+}
+
+func TestStructuredFormat_trailingData(t *testing.T) {
+	_, err := FormatJSON.Decode([]byte(`{"a": 1} {"b": 2}`))
+	AssertNotNil(t, err)
+	_, err = FormatXML.Decode([]byte(`<a>1</a><b>2</b>`))
+	AssertNotNil(t, err)
+	_, err = FormatXML.Decode([]byte(`<a>1</a>garbage`))
+	AssertNotNil(t, err)
+
+	_, err = FormatXML.Decode([]byte("<a>1</a>\n<!-- end -->\n"))
+	AssertNil(t, err)
+}
+
+func TestAssertStructuredEqual_csv(t *testing.T) {
+	AssertStructuredEqual(t,
+		[]byte("name,port\napi,80\n"),
+		[]byte("\"name\",\"port\"\r\n\"api\",80\r\n"),
+		FormatCSV,
+	)
+}
+
+func TestAssertStructuredEqual_gob(t *testing.T) {
+	encode := func(v interface{}) []byte {
+		var buf bytes.Buffer
+		AssertNil(t, gob.NewEncoder(&buf).Encode(v))
+		return buf.Bytes()
+	}
+	AssertStructuredEqual(t,
+		encode(tStructuredConfig{Name: "api", Port: 80, Tags: []string{"a"}, Limits: map[string]float64{"cpu": 0.5}}),
+		encode(&tStructuredConfig{Name: "api", Port: 80, Tags: []string{"a"}, Limits: map[string]float64{"cpu": 0.5}}),
+		FormatGob(tStructuredConfig{}),
+	)
+}
@@ -0,0 +1,125 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// AssertTimeEqual checks that expected and got are the same instant, with
+// time.Time.Equal, ignoring locations and monotonic clock readings.
+func AssertTimeEqual(tb testing.TB, expected, got time.Time, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	if !expected.Equal(got) {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertTimeEqual failed, expected = %s, got = %s, %s, %s", tTimeString(expected), tTimeString(got), tTimeDelta(expected, got), msg)
+		} else {
+			tb.Fatalf("AssertTimeEqual failed, expected = %s, got = %s, %s", tTimeString(expected), tTimeString(got), tTimeDelta(expected, got))
+		}
+	}
+}
+
+// AssertWithinDuration checks that got is at most delta before or after
+// expected.
+func AssertWithinDuration(tb testing.TB, expected, got time.Time, delta time.Duration, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	if d := got.Sub(expected); d < -delta || d > delta {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertWithinDuration failed, expected = %s, got = %s, delta = %v, %s, %s", tTimeString(expected), tTimeString(got), delta, tTimeDelta(expected, got), msg)
+		} else {
+			tb.Fatalf("AssertWithinDuration failed, expected = %s, got = %s, delta = %v, %s", tTimeString(expected), tTimeString(got), delta, tTimeDelta(expected, got))
+		}
+	}
+}
+
+// AssertBefore checks that a is before b.
+func AssertBefore(tb testing.TB, a, b time.Time, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	if !a.Before(b) {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertBefore failed, a = %s, b = %s, a is %s, %s", tTimeString(a), tTimeString(b), tTimeRelation(b, a), msg)
+		} else {
+			tb.Fatalf("AssertBefore failed, a = %s, b = %s, a is %s", tTimeString(a), tTimeString(b), tTimeRelation(b, a))
+		}
+	}
+}
+
+// AssertAfter checks that a is after b.
+func AssertAfter(tb testing.TB, a, b time.Time, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	if !a.After(b) {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertAfter failed, a = %s, b = %s, a is %s, %s", tTimeString(a), tTimeString(b), tTimeRelation(b, a), msg)
+		} else {
+			tb.Fatalf("AssertAfter failed, a = %s, b = %s, a is %s", tTimeString(a), tTimeString(b), tTimeRelation(b, a))
+		}
+	}
+}
+
+// AssertDurationNear checks that got is within abs of expected.
+func AssertDurationNear(tb testing.TB, expected, got, abs time.Duration, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	if d := got - expected; d < -abs || d > abs {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertDurationNear failed, expected = %v, got = %v, abs = %v, diff = %v, %s", expected, got, abs, d, msg)
+		} else {
+			tb.Fatalf("AssertDurationNear failed, expected = %v, got = %v, abs = %v, diff = %v", expected, got, abs, d)
+		}
+	}
+}
+
+// AssertSameDay checks that a and b fall on the same calendar day in loc,
+// or in UTC if loc is nil.
+func AssertSameDay(tb testing.TB, a, b time.Time, loc *time.Location, args ...interface{}) {
+	if x, ok := tb.(testing_TBHelper); ok {
+		x.Helper()
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	ay, am, ad := a.In(loc).Date()
+	by, bm, bd := b.In(loc).Date()
+	if ay != by || am != bm || ad != bd {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertSameDay failed, a = %s, b = %s, loc = %v, %s", tTimeString(a.In(loc)), tTimeString(b.In(loc)), loc, msg)
+		} else {
+			tb.Fatalf("AssertSameDay failed, a = %s, b = %s, loc = %v", tTimeString(a.In(loc)), tTimeString(b.In(loc)), loc)
+		}
+	}
+}
+
+// tTimeString formats t without its monotonic clock reading.
+func tTimeString(t time.Time) string {
+	return t.Format("2006-01-02 15:04:05.999999999 -0700 MST")
+}
+
+// tTimeDelta describes got relative to expected, such as "got is 1.5s later".
+func tTimeDelta(expected, got time.Time) string {
+	return "got is " + tTimeRelation(expected, got)
+}
+
+// tTimeRelation describes t relative to ref, such as "2h0m0s earlier".
+func tTimeRelation(ref, t time.Time) string {
+	switch d := t.Sub(ref); {
+	case d > 0:
+		return fmt.Sprintf("%v later", d)
+	case d < 0:
+		return fmt.Sprintf("%v earlier", -d)
+	default:
+		return "the same instant"
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// go test -assert.failed
+
+package assert
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAssertTimeEqual_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	now := time.Now()
+	AssertTimeEqual(t, now, now.Add(1500*time.Millisecond))
+}
+
+func TestAssertWithinDuration_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	now := time.Now()
+	AssertWithinDuration(t, now, now.Add(-2*time.Hour-3*time.Minute), time.Hour)
+}
+
+func TestAssertBefore_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	now := time.Now()
+	AssertBefore(t, now, now)
+}
+
+func TestAssertAfter_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	now := time.Now()
+	AssertAfter(t, now, now.Add(time.Minute))
+}
+
+func TestAssertDurationNear_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	AssertDurationNear(t, time.Second, 900*time.Millisecond, 50*time.Millisecond)
+}
+
+func TestAssertSameDay_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	a := time.Date(2018, 5, 1, 23, 0, 0, 0, time.UTC)
+	b := time.Date(2018, 5, 2, 1, 0, 0, 0, time.UTC)
+	AssertSameDay(t, a, b, nil)
+}
@@ -0,0 +1,46 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/chai2010/assert"
+)
+
+func TestAssertTimeEqual(t *testing.T) {
+	now := time.Now()
+	AssertTimeEqual(t, now, now.Round(0))
+	AssertTimeEqual(t, now.UTC(), now.In(time.FixedZone("UTC+8", 8*60*60)))
+}
+
+func TestAssertWithinDuration(t *testing.T) {
+	now := time.Now()
+	AssertWithinDuration(t, now, now.Add(time.Second), time.Second)
+	AssertWithinDuration(t, now, now.Add(-time.Second), time.Second)
+}
+
+func TestAssertBefore(t *testing.T) {
+	now := time.Now()
+	AssertBefore(t, now, now.Add(time.Nanosecond))
+}
+
+func TestAssertAfter(t *testing.T) {
+	now := time.Now()
+	AssertAfter(t, now, now.Add(-time.Nanosecond))
+}
+
+func TestAssertDurationNear(t *testing.T) {
+	AssertDurationNear(t, time.Second, 1001*time.Millisecond, 5*time.Millisecond)
+}
+
+func TestAssertSameDay(t *testing.T) {
+	cst := time.FixedZone("CST", 8*60*60)
+	a := time.Date(2018, 5, 1, 23, 0, 0, 0, time.UTC)
+	b := time.Date(2018, 5, 2, 1, 0, 0, 0, time.UTC)
+	AssertSameDay(t, a, b, cst)
+	AssertSameDay(t, a, a.Add(-time.Hour), nil)
+}
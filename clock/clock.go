@@ -0,0 +1,319 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package clock provides a Clock interface over the time package, and a Fake
+clock that tests advance by hand.
+
+Example:
+
+	type Poller struct {
+		Clock clock.Clock
+		...
+	}
+
+	func TestPoller(t *testing.T) {
+		fake := clock.NewFake(time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC))
+		p := &Poller{Clock: fake}
+		go p.Run()
+
+		fake.BlockUntil(1)
+		clock.AssertTimerPending(t, fake, 1)
+		fake.Advance(time.Minute)
+		...
+	}
+
+Code under test takes a Clock, which is Real() in production.
+*/
+package clock
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Clock is the part of the time package that depends on the current time.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+	NewTimer(d time.Duration) Timer
+	NewTicker(d time.Duration) Ticker
+	Sleep(d time.Duration)
+}
+
+// Timer is a time.Timer created by a Clock.
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+// Ticker is a time.Ticker created by a Clock.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+	Reset(d time.Duration)
+}
+
+// Real returns the Clock of the time package.
+func Real() Clock {
+	return realClock{}
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (realClock) NewTimer(d time.Duration) Timer         { return realTimer{time.NewTimer(d)} }
+func (realClock) NewTicker(d time.Duration) Ticker       { return realTicker{time.NewTicker(d)} }
+func (realClock) Sleep(d time.Duration)                  { time.Sleep(d) }
+
+type realTimer struct{ t *time.Timer }
+
+func (x realTimer) C() <-chan time.Time        { return x.t.C }
+func (x realTimer) Stop() bool                 { return x.t.Stop() }
+func (x realTimer) Reset(d time.Duration) bool { return x.t.Reset(d) }
+
+type realTicker struct{ t *time.Ticker }
+
+func (x realTicker) C() <-chan time.Time   { return x.t.C }
+func (x realTicker) Stop()                 { x.t.Stop() }
+func (x realTicker) Reset(d time.Duration) { x.t.Reset(d) }
+
+// Fake is a Clock whose time only moves by Advance and Set.
+// Timers, tickers and sleepers fire when the fake time reaches their
+// deadline. Like the time package, channels have a buffer of one and
+// tickers drop ticks the receiver is too slow for.
+type Fake struct {
+	mu      sync.Mutex
+	changed *sync.Cond
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+// fakeWaiter is a pending timer, ticker or sleeper.
+type fakeWaiter struct {
+	kind   string
+	when   time.Time
+	period time.Duration // for tickers
+	c      chan time.Time
+}
+
+// NewFake returns a fake clock set to start.
+func NewFake(start time.Time) *Fake {
+	f := &Fake{now: start}
+	f.changed = sync.NewCond(&f.mu)
+	return f
+}
+
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	return f.newWaiter("after", d, 0).c
+}
+
+func (f *Fake) NewTimer(d time.Duration) Timer {
+	return &fakeTimer{f: f, w: f.newWaiter("timer", d, 0)}
+}
+
+// NewTicker panics if d <= 0, like time.NewTicker.
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("clock: non-positive interval for NewTicker")
+	}
+	return &fakeTicker{f: f, w: f.newWaiter("ticker", d, d)}
+}
+
+// Sleep blocks until the fake time has advanced by d.
+func (f *Fake) Sleep(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	<-f.newWaiter("sleep", d, 0).c
+}
+
+// Advance moves the fake time forward by d, firing every timer, ticker and
+// sleeper due on the way, in deadline order.
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.advanceTo(f.now.Add(d))
+}
+
+// Set moves the fake time to t, firing what is due if t is later.
+func (f *Fake) Set(t time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if t.After(f.now) {
+		f.advanceTo(t)
+	} else {
+		f.now = t
+	}
+}
+
+// Pending returns the number of timers, tickers and sleepers waiting on f.
+func (f *Fake) Pending() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.waiters)
+}
+
+// BlockUntil waits until at least n timers, tickers and sleepers are
+// waiting on f. It lets a test synchronize with goroutines that schedule
+// work before calling Advance.
+func (f *Fake) BlockUntil(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for len(f.waiters) < n {
+		f.changed.Wait()
+	}
+}
+
+func (f *Fake) newWaiter(kind string, d, period time.Duration) *fakeWaiter {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w := &fakeWaiter{kind: kind, period: period, c: make(chan time.Time, 1)}
+	f.schedule(w, d)
+	return w
+}
+
+// schedule adds w with a deadline d from now, or fires it at once if d <= 0.
+func (f *Fake) schedule(w *fakeWaiter, d time.Duration) {
+	w.when = f.now.Add(d)
+	if d <= 0 && w.period == 0 {
+		w.fire(f.now)
+		return
+	}
+	f.waiters = append(f.waiters, w)
+	f.changed.Broadcast()
+}
+
+func (f *Fake) remove(w *fakeWaiter) bool {
+	for i, x := range f.waiters {
+		if x == w {
+			f.waiters = append(f.waiters[:i], f.waiters[i+1:]...)
+			f.changed.Broadcast()
+			return true
+		}
+	}
+	return false
+}
+
+func (f *Fake) advanceTo(t time.Time) {
+	for {
+		var next *fakeWaiter
+		for _, w := range f.waiters {
+			if !w.when.After(t) && (next == nil || w.when.Before(next.when)) {
+				next = w
+			}
+		}
+		if next == nil {
+			break
+		}
+		f.now = next.when
+		next.fire(f.now)
+		if next.period > 0 {
+			next.when = next.when.Add(next.period)
+		} else {
+			f.remove(next)
+		}
+	}
+	f.now = t
+}
+
+func (w *fakeWaiter) fire(now time.Time) {
+	select {
+	case w.c <- now:
+	default:
+	}
+}
+
+type fakeTimer struct {
+	f *Fake
+	w *fakeWaiter
+}
+
+func (x *fakeTimer) C() <-chan time.Time { return x.w.c }
+
+func (x *fakeTimer) Stop() bool {
+	x.f.mu.Lock()
+	defer x.f.mu.Unlock()
+	return x.f.remove(x.w)
+}
+
+func (x *fakeTimer) Reset(d time.Duration) bool {
+	x.f.mu.Lock()
+	defer x.f.mu.Unlock()
+	active := x.f.remove(x.w)
+	x.f.schedule(x.w, d)
+	return active
+}
+
+type fakeTicker struct {
+	f *Fake
+	w *fakeWaiter
+}
+
+func (x *fakeTicker) C() <-chan time.Time { return x.w.c }
+
+func (x *fakeTicker) Stop() {
+	x.f.mu.Lock()
+	defer x.f.mu.Unlock()
+	x.f.remove(x.w)
+}
+
+// Reset panics if d <= 0, like time.Ticker.Reset.
+func (x *fakeTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("clock: non-positive interval for Ticker.Reset")
+	}
+	x.f.mu.Lock()
+	defer x.f.mu.Unlock()
+	x.f.remove(x.w)
+	x.w.period = d
+	x.f.schedule(x.w, d)
+}
+
+// AssertTimerPending checks that n timers, tickers and sleepers are waiting
+// on fake, and lists them if not.
+func AssertTimerPending(tb testing.TB, fake *Fake, n int, args ...interface{}) {
+	if x, ok := tb.(interface{ Helper() }); ok {
+		x.Helper()
+	}
+	fake.mu.Lock()
+	got, pending := len(fake.waiters), fake.describe()
+	fake.mu.Unlock()
+
+	if got != n {
+		if msg := fmt.Sprint(args...); msg != "" {
+			tb.Fatalf("AssertTimerPending failed, expected = %d, got = %d, %s%s", n, got, msg, pending)
+		} else {
+			tb.Fatalf("AssertTimerPending failed, expected = %d, got = %d%s", n, got, pending)
+		}
+	}
+}
+
+// describe lists the waiters in deadline order, one per line.
+func (f *Fake) describe() string {
+	waiters := append([]*fakeWaiter(nil), f.waiters...)
+	sort.SliceStable(waiters, func(i, j int) bool {
+		return waiters[i].when.Before(waiters[j].when)
+	})
+	var buf strings.Builder
+	for _, w := range waiters {
+		fmt.Fprintf(&buf, "\n\t%s due in %v", w.kind, w.when.Sub(f.now))
+		if w.period > 0 {
+			fmt.Fprintf(&buf, ", every %v", w.period)
+		}
+	}
+	return buf.String()
+}
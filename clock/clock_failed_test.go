@@ -0,0 +1,27 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// go test -assert.failed
+
+package clock
+
+import (
+	"flag"
+	"testing"
+	"time"
+)
+
+var (
+	flagAssertFailedTest = flag.Bool("assert.failed", false, "run assert failed test")
+)
+
+func TestAssertTimerPending_failed(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	fake := NewFake(tStart)
+	fake.NewTicker(time.Minute)
+	fake.After(time.Second)
+	AssertTimerPending(t, fake, 1)
+}
@@ -0,0 +1,108 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+var tStart = time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestReal(t *testing.T) {
+	var c Clock = Real()
+	if c.Now().IsZero() {
+		t.Fatalf("Real().Now() is zero")
+	}
+	timer := c.NewTimer(time.Hour)
+	if !timer.Stop() {
+		t.Fatalf("Real timer not active")
+	}
+}
+
+func TestFake_timer(t *testing.T) {
+	fake := NewFake(tStart)
+	timer := fake.NewTimer(time.Second)
+	AssertTimerPending(t, fake, 1)
+
+	fake.Advance(999 * time.Millisecond)
+	select {
+	case <-timer.C():
+		t.Fatalf("timer fired early")
+	default:
+	}
+
+	fake.Advance(time.Millisecond)
+	if got := <-timer.C(); !got.Equal(tStart.Add(time.Second)) {
+		t.Fatalf("timer fired at %v", got)
+	}
+	AssertTimerPending(t, fake, 0)
+
+	if timer.Reset(time.Second) {
+		t.Fatalf("Reset of a fired timer returned true")
+	}
+	if !timer.Stop() {
+		t.Fatalf("Stop of a pending timer returned false")
+	}
+	AssertTimerPending(t, fake, 0)
+}
+
+func TestFake_ticker(t *testing.T) {
+	fake := NewFake(tStart)
+	ticker := fake.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	fake.Advance(time.Second)
+	if got := <-ticker.C(); !got.Equal(tStart.Add(time.Second)) {
+		t.Fatalf("tick at %v", got)
+	}
+
+	// Ticks the receiver misses are dropped.
+	fake.Advance(3 * time.Second)
+	if got := <-ticker.C(); !got.Equal(tStart.Add(2 * time.Second)) {
+		t.Fatalf("tick at %v", got)
+	}
+	select {
+	case got := <-ticker.C():
+		t.Fatalf("unexpected tick at %v", got)
+	default:
+	}
+	if got := fake.Now(); !got.Equal(tStart.Add(4 * time.Second)) {
+		t.Fatalf("Now() = %v", got)
+	}
+	AssertTimerPending(t, fake, 1)
+}
+
+func TestFake_sleep(t *testing.T) {
+	fake := NewFake(tStart)
+	done := make(chan time.Time)
+	go func() {
+		fake.Sleep(time.Minute)
+		done <- fake.Now()
+	}()
+
+	fake.BlockUntil(1)
+	AssertTimerPending(t, fake, 1)
+	fake.Advance(time.Minute)
+	if got := <-done; !got.Equal(tStart.Add(time.Minute)) {
+		t.Fatalf("woke at %v", got)
+	}
+}
+
+func TestFake_order(t *testing.T) {
+	fake := NewFake(tStart)
+	a, b := fake.After(2*time.Second), fake.After(time.Second)
+	fake.Advance(time.Hour)
+	if ta, tb := <-a, <-b; !tb.Before(ta) {
+		t.Fatalf("a fired at %v, b fired at %v", ta, tb)
+	}
+}
+
+func TestFake_zero(t *testing.T) {
+	fake := NewFake(tStart)
+	<-fake.After(0)
+	fake.Sleep(0)
+	AssertTimerPending(t, fake, 0)
+}
@@ -3,3 +3,5 @@
 // license that can be found in the LICENSE file.
 
 module github.com/chai2010/assert
+
+go 1.21
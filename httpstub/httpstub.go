@@ -0,0 +1,289 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package httpstub provides a stub HTTP server for testing HTTP clients.
+
+Example:
+
+	func TestClient(t *testing.T) {
+		s := httpstub.New(t)
+		s.Expect("POST", "/v1/items").WithJSONBody(`{"name": "x"}`).Respond(201, `{"id": 1}`)
+		s.Expect("GET", "/v1/items/1").Respond(200, map[string]interface{}{"id": 1, "name": "x"})
+
+		client := NewClient(s.URL)
+		...
+	}
+
+Requests must arrive in the order the expectations were declared. When the
+test finishes, the server is closed and the test fails if an expectation
+was not met or an unexpected request was received.
+*/
+package httpstub
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Server is a stub HTTP server running on a local address.
+type Server struct {
+	*httptest.Server
+
+	tb testing.TB
+
+	mu           sync.Mutex
+	expectations []*Expectation
+	next         int
+	unexpected   []string
+}
+
+// Expectation is a request the server expects, and the response it sends.
+// Its methods hold the server lock, so an expectation can be declared while
+// requests are in flight.
+type Expectation struct {
+	s *Server
+
+	method string
+	path   string
+	query  map[string]string
+	header map[string]string
+
+	body     []byte
+	jsonBody interface{}
+	hasJSON  bool
+
+	status         int
+	responseHeader http.Header
+	responseBody   []byte
+}
+
+// New starts a stub server, which is closed and verified by tb.Cleanup.
+func New(tb testing.TB) *Server {
+	s := &Server{tb: tb}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	tb.Cleanup(s.verify)
+	tb.Cleanup(s.Close)
+	return s
+}
+
+// Expect adds an expectation for the next request.
+// The default response is 200 with an empty body.
+func (s *Server) Expect(method, path string) *Expectation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := &Expectation{
+		s:              s,
+		method:         strings.ToUpper(method),
+		path:           path,
+		status:         http.StatusOK,
+		responseHeader: make(http.Header),
+	}
+	s.expectations = append(s.expectations, e)
+	return e
+}
+
+// WithQuery expects the query parameter key to have value.
+func (e *Expectation) WithQuery(key, value string) *Expectation {
+	e.s.mu.Lock()
+	defer e.s.mu.Unlock()
+
+	if e.query == nil {
+		e.query = make(map[string]string)
+	}
+	e.query[key] = value
+	return e
+}
+
+// WithHeader expects the request header key to have value. A header with
+// several values is compared as the values joined with ", ".
+func (e *Expectation) WithHeader(key, value string) *Expectation {
+	e.s.mu.Lock()
+	defer e.s.mu.Unlock()
+
+	if e.header == nil {
+		e.header = make(map[string]string)
+	}
+	e.header[http.CanonicalHeaderKey(key)] = value
+	return e
+}
+
+// WithBody expects the request body to be exactly body.
+func (e *Expectation) WithBody(body string) *Expectation {
+	e.s.mu.Lock()
+	defer e.s.mu.Unlock()
+
+	e.body = []byte(body)
+	return e
+}
+
+// WithJSONBody expects a JSON request body equal to v, which is either JSON
+// text (string or []byte) or a value encoded with encoding/json. Bodies are
+// compared after decoding, so formatting and key order do not matter.
+func (e *Expectation) WithJSONBody(v interface{}) *Expectation {
+	data, err := tJSONBytes(v)
+	if err != nil {
+		panic(fmt.Sprintf("httpstub: WithJSONBody called with invalid value, err = %v", err))
+	}
+	var jsonBody interface{}
+	if err := json.Unmarshal(data, &jsonBody); err != nil {
+		panic(fmt.Sprintf("httpstub: WithJSONBody called with invalid JSON, err = %v", err))
+	}
+
+	e.s.mu.Lock()
+	defer e.s.mu.Unlock()
+
+	e.jsonBody = jsonBody
+	e.hasJSON = true
+	return e
+}
+
+// Respond sets the response. A string or []byte body is sent as is, any
+// other value is encoded with encoding/json.
+func (e *Expectation) Respond(status int, body interface{}) *Expectation {
+	var data []byte
+	isJSON := false
+	switch x := body.(type) {
+	case nil:
+	case string:
+		data = []byte(x)
+	case []byte:
+		data = x
+	default:
+		var err error
+		if data, err = json.Marshal(x); err != nil {
+			panic(fmt.Sprintf("httpstub: Respond called with invalid body, err = %v", err))
+		}
+		isJSON = true
+	}
+
+	e.s.mu.Lock()
+	defer e.s.mu.Unlock()
+
+	e.status = status
+	e.responseBody = data
+	if isJSON && e.responseHeader.Get("Content-Type") == "" {
+		e.responseHeader.Set("Content-Type", "application/json")
+	}
+	return e
+}
+
+// RespondHeader adds a response header.
+func (e *Expectation) RespondHeader(key, value string) *Expectation {
+	e.s.mu.Lock()
+	defer e.s.mu.Unlock()
+
+	e.responseHeader.Add(key, value)
+	return e
+}
+
+func (e *Expectation) String() string {
+	return e.method + " " + e.path
+}
+
+// mismatch returns why r does not meet e, or "" if it does.
+func (e *Expectation) mismatch(r *http.Request, body []byte) string {
+	if r.Method != e.method || r.URL.Path != e.path {
+		return fmt.Sprintf("expected %s", e)
+	}
+	for k, v := range e.query {
+		if got := r.URL.Query().Get(k); got != v {
+			return fmt.Sprintf("query %s: expected = %q, got = %q", k, v, got)
+		}
+	}
+	for k, v := range e.header {
+		if got := strings.Join(r.Header[k], ", "); got != v {
+			return fmt.Sprintf("header %s: expected = %q, got = %q", k, v, got)
+		}
+	}
+	if e.body != nil && !bytes.Equal(e.body, body) {
+		return fmt.Sprintf("body: expected = %q", e.body)
+	}
+	if e.hasJSON {
+		var got interface{}
+		if err := json.Unmarshal(body, &got); err != nil {
+			return fmt.Sprintf("body: invalid JSON, err = %v", err)
+		}
+		if !reflect.DeepEqual(e.jsonBody, got) {
+			expected, _ := json.Marshal(e.jsonBody)
+			return fmt.Sprintf("body: expected JSON = %s", expected)
+		}
+	}
+	return ""
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	var e *Expectation
+	reason := "no more requests expected"
+	if s.next < len(s.expectations) {
+		reason = s.expectations[s.next].mismatch(r, body)
+		if reason == "" {
+			e = s.expectations[s.next]
+			s.next++
+		}
+	}
+	if e == nil {
+		s.unexpected = append(s.unexpected, fmt.Sprintf(
+			"unexpected request %s %s (%s)\n\t\tbody = %q",
+			r.Method, r.URL.RequestURI(), reason, body,
+		))
+		s.mu.Unlock()
+		http.Error(w, "httpstub: unexpected request, "+reason, http.StatusInternalServerError)
+		return
+	}
+	status, header, responseBody := e.status, e.responseHeader.Clone(), e.responseBody
+	s.mu.Unlock()
+
+	for k, v := range header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(status)
+	w.Write(responseBody)
+}
+
+func (s *Server) verify() {
+	if x, ok := s.tb.(interface{ Helper() }); ok {
+		x.Helper()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var failures []string
+	failures = append(failures, s.unexpected...)
+	for i := s.next; i < len(s.expectations); i++ {
+		failures = append(failures, fmt.Sprintf("expectation %d not met: %s", i+1, s.expectations[i]))
+	}
+	if len(failures) != 0 {
+		s.tb.Errorf("httpstub failed, %d of %d expectations met\n\t%s",
+			s.next, len(s.expectations), strings.Join(failures, "\n\t"),
+		)
+	}
+}
+
+func tJSONBytes(v interface{}) ([]byte, error) {
+	switch x := v.(type) {
+	case string:
+		return []byte(x), nil
+	case []byte:
+		return x, nil
+	}
+	return json.Marshal(v)
+}
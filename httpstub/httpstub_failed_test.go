@@ -0,0 +1,45 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// go test -assert.failed
+
+package httpstub
+
+import (
+	"flag"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+var (
+	flagAssertFailedTest = flag.Bool("assert.failed", false, "run assert failed test")
+)
+
+func TestServer_failed_unexpected(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	s := New(t)
+	s.Expect("GET", "/a")
+
+	if resp, err := http.Post(s.URL+"/b", "text/plain", strings.NewReader("hello")); err == nil {
+		resp.Body.Close()
+	}
+}
+
+func TestServer_failed_order(t *testing.T) {
+	if !*flagAssertFailedTest {
+		t.SkipNow()
+	}
+	s := New(t)
+	s.Expect("GET", "/a")
+	s.Expect("POST", "/b").WithJSONBody(`{"n": 1}`)
+
+	for _, path := range []string{"/b", "/a"} {
+		if resp, err := http.Post(s.URL+path, "application/json", strings.NewReader(`{"n": 2}`)); err == nil {
+			resp.Body.Close()
+		}
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2014 <chaishushan{AT}gmail.com>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package httpstub_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	. "github.com/chai2010/assert"
+	"github.com/chai2010/assert/httpstub"
+)
+
+func TestServer(t *testing.T) {
+	s := httpstub.New(t)
+	s.Expect("POST", "/v1/items").
+		WithHeader("Content-Type", "application/json").
+		WithJSONBody(map[string]interface{}{"name": "x"}).
+		Respond(201, `{"id": 1}`)
+	s.Expect("GET", "/v1/items").
+		WithQuery("limit", "10").
+		Respond(200, []map[string]interface{}{{"id": 1, "name": "x"}})
+
+	resp, err := http.Post(s.URL+"/v1/items", "application/json", strings.NewReader(`{ "name" : "x" }`))
+	AssertNil(t, err)
+	AssertHTTPStatus(t, resp, nil, 201)
+	AssertHTTPBodyJSON(t, resp, nil, `{"id": 1}`)
+
+	resp, err = http.Get(s.URL + "/v1/items?limit=10")
+	AssertNil(t, err)
+	AssertHTTPHeader(t, resp, nil, "Content-Type", "application/json")
+	AssertHTTPBodyJSON(t, resp, nil, `[{"id": 1, "name": "x"}]`)
+}
+
+func TestServer_expectDuringRequest(t *testing.T) {
+	s := httpstub.New(t)
+	e := s.Expect("GET", "/v1/items")
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if resp, err := http.Get(s.URL + "/v1/items"); err == nil {
+			resp.Body.Close()
+		}
+	}()
+	e.Respond(200, []int{1}).RespondHeader("X-Total", "1")
+	<-done
+}